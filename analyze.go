@@ -0,0 +1,209 @@
+package govtl
+
+import (
+	"sort"
+	"strings"
+)
+
+// Reference is a use of the variable not bound in the template
+type Reference struct {
+	Name string
+	// Path lists accessed properties, methods are followed by () and
+	// indexes are written as []
+	Path   []string
+	Line   int
+	Column int
+}
+
+func (r Reference) String() string {
+	var b strings.Builder
+	b.WriteByte('$')
+	b.WriteString(r.Name)
+	for _, p := range r.Path {
+		if !strings.HasPrefix(p, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+// Analysis describes what the template uses
+type Analysis struct {
+	// Refs are uses of free variables in order of appearance, each path is
+	// listed once
+	Refs []Reference
+	// Vars are names of free variables, sorted
+	Vars []string
+	// MacrosCalled and MacrosDefined are sorted names of macros
+	MacrosCalled  []string
+	MacrosDefined []string
+	// Includes and Parses are targets of #include and #parse given as
+	// string literals, in order of appearance
+	Includes []string
+	Parses   []string
+}
+
+// Analyze walks the template and reports free variables, macros and
+// templates it uses. Variables bound by #set, #define, #foreach and macro
+// parameters are not free after they are bound. Bindings inside blocks are
+// visible only in the block, as the block could be skipped
+func (t *Template) Analyze() *Analysis {
+	a := &analyzer{refs: make(map[string]bool), vars: make(map[string]bool),
+		called: make(map[string]bool), defined: make(map[string]bool)}
+	a.walk(t.tree, scope{})
+	a.Vars = sortedKeys(a.vars)
+	a.MacrosCalled = sortedKeys(a.called)
+	a.MacrosDefined = sortedKeys(a.defined)
+	return &a.Analysis
+}
+
+type analyzer struct {
+	Analysis
+	refs, vars, called, defined map[string]bool
+}
+
+// scope is a set of bound variables
+type scope map[string]bool
+
+func (s scope) with(names ...string) scope {
+	c := make(scope, len(s)+len(names))
+	for k := range s {
+		c[k] = true
+	}
+	for _, n := range names {
+		c[n] = true
+	}
+	return c
+}
+
+func (a *analyzer) walk(list []Node, bound scope) {
+	for _, node := range list {
+		inner := bound
+		switch n := node.(type) {
+		case *VarNode:
+			a.ref(n, bound)
+		case *SetNode:
+			a.expr(n.Expr, bound)
+			if len(n.Var.Items) == 0 {
+				bound[n.Var.Name] = true
+			} else {
+				a.ref(n.Var, bound)
+			}
+		case *IfNode:
+			for c := n; c != nil; c = c.Else {
+				a.expr(c.Cond, bound)
+			}
+		case *ForeachNode:
+			a.expr(n.Iter, bound)
+			inner = bound.with(n.Var.Name, "foreach")
+		case *MacroNode:
+			a.defined[n.Name] = true
+			inner = bound.with("bodyContent")
+			for _, p := range n.Assign {
+				inner[p.Name] = true
+			}
+		case *MacroCall:
+			a.called[n.Name] = true
+			a.exprs(n.Vals, bound)
+		case *DirectiveNode:
+			a.exprs(n.Args, bound)
+		case *DefineNode:
+			bound[n.Var.Name] = true
+		case *IncludeNode:
+			for _, name := range n.Names {
+				if s, ok := staticString(name); ok {
+					a.Includes = append(a.Includes, s)
+				}
+			}
+			a.exprs(n.Names, bound)
+		case *ParseNode:
+			if s, ok := staticString(n.Name); ok {
+				a.Parses = append(a.Parses, s)
+			}
+			a.expr(n.Name, bound)
+		case *EvalNode:
+			a.expr(n.Content, bound)
+		}
+		if nested, ok := node.(NestedNode); ok {
+			for _, items := range nested.Nested() {
+				a.walk(items, inner.with())
+			}
+		}
+	}
+}
+
+func (a *analyzer) ref(n *VarNode, bound scope) {
+	if !bound[n.Name] {
+		r := Reference{Name: n.Name, Line: n.Pos.line, Column: n.Pos.col}
+		for _, item := range n.Items {
+			switch item.Kind {
+			case AccessMethod:
+				r.Path = append(r.Path, item.Name+"()")
+			case AccessIndex:
+				r.Path = append(r.Path, "[]")
+			default:
+				r.Path = append(r.Path, item.Name)
+			}
+		}
+		a.vars[n.Name] = true
+		if s := r.String(); !a.refs[s] {
+			a.refs[s] = true
+			a.Refs = append(a.Refs, r)
+		}
+	}
+	for _, item := range n.Items {
+		a.exprs(item.Args, bound)
+	}
+	a.expr(n.Alt, bound)
+}
+
+func (a *analyzer) exprs(list []*OpNode, bound scope) {
+	for _, e := range list {
+		a.expr(e, bound)
+	}
+}
+
+func (a *analyzer) expr(e *OpNode, bound scope) {
+	if e == nil {
+		return
+	}
+	a.expr(e.Left, bound)
+	a.expr(e.Right, bound)
+	switch v := e.Val.(type) {
+	case *VarNode:
+		a.ref(v, bound)
+	case *InterpolatedNode:
+		a.walk(v.Items, bound)
+	case []*OpNode:
+		a.exprs(v, bound)
+	}
+}
+
+// staticString returns the value of string literal without references
+func staticString(e *OpNode) (string, bool) {
+	switch v := e.Val.(type) {
+	case string:
+		return v, e.Op == ""
+	case *InterpolatedNode:
+		var b strings.Builder
+		for _, item := range v.Items {
+			t, ok := item.(TextNode)
+			if !ok {
+				return "", false
+			}
+			b.WriteString(string(t))
+		}
+		return b.String(), true
+	}
+	return "", false
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,116 @@
+package govtl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name   string
+		tmpl   string
+		expect Analysis
+	}{
+		{"paths",
+			`$user.name $user.address.city $user.getName() $list[0].id $user.name $!{title|$default}`,
+			Analysis{Refs: []Reference{
+				{"user", []string{"name"}, 1, 1},
+				{"user", []string{"address", "city"}, 1, 12},
+				{"user", []string{"getName()"}, 1, 31},
+				{"list", []string{"[]", "id"}, 1, 47},
+				{"title", nil, 1, 70},
+				{"default", nil, 1, 79},
+			}, Vars: []string{"default", "list", "title", "user"}},
+		},
+		{"set binds after evaluation",
+			`$x #set($x = $x + $y)$x #set($m.k = 1)`,
+			Analysis{Refs: []Reference{
+				{"x", nil, 1, 1},
+				{"y", nil, 1, 19},
+				{"m", []string{"k"}, 1, 30},
+			}, Vars: []string{"m", "x", "y"}},
+		},
+		{"set inside block",
+			`#if($a)#set($b = 1)$b#else$b#end$b`,
+			Analysis{Refs: []Reference{
+				{"a", nil, 1, 5},
+				{"b", nil, 1, 27},
+			}, Vars: []string{"a", "b"}},
+		},
+		{"elseif conditions",
+			`#if($a)#elseif($b.c)#end`,
+			Analysis{Refs: []Reference{
+				{"a", nil, 1, 5},
+				{"b", []string{"c"}, 1, 16},
+			}, Vars: []string{"a", "b"}},
+		},
+		{"foreach",
+			`#foreach($i in $items)$i.name $foreach.count $other#else$i#end$i`,
+			Analysis{Refs: []Reference{
+				{"items", nil, 1, 16},
+				{"other", nil, 1, 46},
+				{"i", nil, 1, 63},
+			}, Vars: []string{"i", "items", "other"}},
+		},
+		{"macros",
+			`#macro(card $title)$title $bodyContent $global#end#@card($t)$inner#end#card('x')#other()`,
+			Analysis{Refs: []Reference{
+				{"global", nil, 1, 40},
+				{"t", nil, 1, 58},
+				{"inner", nil, 1, 61},
+			}, Vars: []string{"global", "inner", "t"},
+				MacrosCalled: []string{"card"}, MacrosDefined: []string{"card"}},
+		},
+		{"define",
+			`#define($block)$a#end$block`,
+			Analysis{Refs: []Reference{{"a", nil, 1, 16}}, Vars: []string{"a"}},
+		},
+		{"strings and collections",
+			`#set($l = ["$a", 'b$c', {"k": $v}, [1..$n]])`,
+			Analysis{Refs: []Reference{
+				{"a", nil, 1, 13},
+				{"v", nil, 1, 31},
+				{"n", nil, 1, 40},
+			}, Vars: []string{"a", "n", "v"}},
+		},
+		{"includes",
+			`#include('a.vm', "b.vm", $c, "d$e.vm")#parse('p.vm')#parse($f)#evaluate($g)`,
+			Analysis{Refs: []Reference{
+				{"c", nil, 1, 26},
+				{"e", []string{"vm"}, 1, 32},
+				{"f", nil, 1, 60},
+				{"g", nil, 1, 73},
+			}, Vars: []string{"c", "e", "f", "g"},
+				Includes: []string{"a.vm", "b.vm"}, Parses: []string{"p.vm"}},
+		},
+		{"method arguments",
+			`$a.b($c, $d.e)`,
+			Analysis{Refs: []Reference{
+				{"a", []string{"b()"}, 1, 1},
+				{"c", nil, 1, 6},
+				{"d", []string{"e"}, 1, 10},
+			}, Vars: []string{"a", "c", "d"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			require.NoError(t, err)
+			a := tmpl.Analyze()
+			if test.expect.MacrosCalled == nil {
+				test.expect.MacrosCalled = []string{}
+			}
+			if test.expect.MacrosDefined == nil {
+				test.expect.MacrosDefined = []string{}
+			}
+			assert.Equal(t, test.expect, *a)
+		})
+	}
+}
+
+func TestReferenceString(t *testing.T) {
+	assert.Equal(t, "$a", Reference{Name: "a"}.String())
+	assert.Equal(t, "$a.b[].c()", Reference{Name: "a", Path: []string{"b", "[]", "c()"}}.String())
+}
@@ -3,7 +3,7 @@ package govtl
 type Node interface{}
 
 type Pos struct {
-	line int
+	line, col int
 }
 
 type PositionedNode interface {
@@ -18,6 +18,7 @@ type VarNode struct {
 	*RefNode
 	Items  []*AccessNode
 	Silent bool
+	Alt    *OpNode
 	Pos    Pos
 }
 
@@ -105,27 +106,56 @@ func (n *ForeachNode) Nested() [][]Node { return [][]Node{n.Items, n.Else} }
 type MacroCall struct {
 	Name string
 	Vals []*OpNode
+	Body []Node
 	Pos  Pos
 }
 
 func (n *MacroCall) Position() Pos { return n.Pos }
 
+func (n *MacroCall) Nested() [][]Node { return [][]Node{n.Body} }
+
+type DirectiveNode struct {
+	Name string
+	Args []*OpNode
+	Body []Node
+	Pos  Pos
+	d    Directive
+}
+
+func (n *DirectiveNode) Position() Pos { return n.Pos }
+
+func (n *DirectiveNode) Nested() [][]Node { return [][]Node{n.Body} }
+
 type MacroNode struct {
 	Name   string
 	Assign []*RefNode
 	Items  []Node
 	Pos    Pos
+	// name of the template where macro is defined
+	file string
 }
 
 func (n *MacroNode) Position() Pos { return n.Pos }
 
 func (n *MacroNode) Nested() [][]Node { return [][]Node{n.Items} }
 
+type DefineNode struct {
+	Var   *RefNode
+	Items []Node
+	Pos   Pos
+}
+
+func (n *DefineNode) Position() Pos { return n.Pos }
+
+func (n *DefineNode) Nested() [][]Node { return [][]Node{n.Items} }
+
 type ParseNode struct {
 	Name *OpNode
 	Pos  Pos
 }
 
+func (n *ParseNode) Position() Pos { return n.Pos }
+
 type StopNode struct{}
 type BreakNode struct{}
 
@@ -137,7 +167,7 @@ type IncludeNode struct {
 func (n *IncludeNode) Position() Pos { return n.Pos }
 
 type EvalNode struct {
-	Content string
+	Content *OpNode
 	Pos     Pos
 }
 
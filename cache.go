@@ -0,0 +1,79 @@
+package govtl
+
+import (
+	"sync"
+	"time"
+)
+
+// StatLoader is implemented by loaders which can resolve template name to
+// the canonical one and report its modification time. Canonical name is used
+// as a key in TemplateCache
+type StatLoader interface {
+	Loader
+	Stat(name string) (key string, modTime time.Time, err error)
+}
+
+// TemplateCache keeps templates parsed by #parse. It is safe to share it
+// between templates using the same loader and macro library
+type TemplateCache struct {
+	mu           sync.Mutex
+	entries      map[string]cacheEntry
+	checkModTime bool
+}
+
+type cacheEntry struct {
+	tree    []Node
+	modTime time.Time
+}
+
+// NewTemplateCache creates new cache, if checkModTime is true, modification
+// time of the template is checked on every #parse and changed templates are
+// parsed again
+func NewTemplateCache(checkModTime bool) *TemplateCache {
+	return &TemplateCache{entries: make(map[string]cacheEntry), checkModTime: checkModTime}
+}
+
+func (c *TemplateCache) get(key string, modTime time.Time) ([]Node, bool) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if !ok || c.checkModTime && !e.modTime.Equal(modTime) {
+		return nil, false
+	}
+	return e.tree, true
+}
+
+func (c *TemplateCache) put(key string, modTime time.Time, tree []Node) {
+	c.mu.Lock()
+	c.entries[key] = cacheEntry{tree, modTime}
+	c.mu.Unlock()
+}
+
+// parseTree loads and parses template name for #parse, using the cache if
+// it's enabled
+func (t *Template) parseTree(name string) ([]Node, error) {
+	key, modTime := name, time.Time{}
+	if t.cache != nil {
+		if sl, ok := t.loader.(StatLoader); ok {
+			var err error
+			if key, modTime, err = sl.Stat(name); err != nil {
+				return nil, err
+			}
+		}
+		if tree, ok := t.cache.get(key, modTime); ok {
+			return tree, nil
+		}
+	}
+	data, err := t.loader.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := parse(name, string(data), t.directives, t.macros)
+	if err != nil {
+		return nil, err
+	}
+	if t.cache != nil {
+		t.cache.put(key, modTime, tree)
+	}
+	return tree, nil
+}
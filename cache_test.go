@@ -0,0 +1,143 @@
+package govtl
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingLoader struct {
+	Loader
+	loads int32
+}
+
+func (l *countingLoader) Load(name string) ([]byte, error) {
+	atomic.AddInt32(&l.loads, 1)
+	return l.Loader.Load(name)
+}
+
+func TestTemplateCache(t *testing.T) {
+	loader := &countingLoader{Loader: MapLoader{
+		"lib.vm": `#macro(hello $n)Hello $n#end`,
+		"row.vm": `#hello($i)`,
+	}}
+	tests := []struct {
+		name    string
+		disable bool
+		expect  int32
+	}{
+		{"enabled by default", false, 2},
+		{"disabled", true, 11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader.loads = 0
+			tmpl, err := ParseWithLoader(`#foreach($i in [1..10])#parse("row.vm")#end`, loader, "lib.vm")
+			require.NoError(t, err)
+			if tt.disable {
+				tmpl.WithTemplateCache(nil)
+			}
+			var buf bytes.Buffer
+			require.NoError(t, tmpl.Execute(&buf, nil))
+			assert.True(t, strings.HasPrefix(buf.String(), "Hello 1Hello 2"))
+			assert.Equal(t, tt.expect, loader.loads)
+		})
+	}
+}
+
+func TestTemplateCacheShared(t *testing.T) {
+	loader := &countingLoader{Loader: MapLoader{"row.vm": `row`}}
+	cache := NewTemplateCache(false)
+	for i := 0; i < 3; i++ {
+		tmpl, err := ParseWithLoader(`#parse("row.vm")`, loader, "")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, tmpl.WithTemplateCache(cache).Execute(&buf, nil))
+		assert.Equal(t, "row", buf.String())
+	}
+	assert.EqualValues(t, 1, loader.loads)
+}
+
+func TestTemplateCacheModTime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "govtl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	row := filepath.Join(dir, "row.vm")
+	write := func(content string, mtime time.Time) {
+		require.NoError(t, ioutil.WriteFile(row, []byte(content), 0644))
+		require.NoError(t, os.Chtimes(row, mtime, mtime))
+	}
+	now := time.Now()
+
+	for _, check := range []bool{false, true} {
+		write("old", now)
+		tmpl, err := Parse(`#parse("row.vm")`, dir, "")
+		require.NoError(t, err)
+		tmpl.WithTemplateCache(NewTemplateCache(check))
+		var buf bytes.Buffer
+		require.NoError(t, tmpl.Execute(&buf, nil))
+		assert.Equal(t, "old", buf.String())
+
+		write("new", now.Add(time.Minute))
+		buf.Reset()
+		require.NoError(t, tmpl.Execute(&buf, nil))
+		if check {
+			assert.Equal(t, "new", buf.String())
+		} else {
+			assert.Equal(t, "old", buf.String())
+		}
+	}
+}
+
+func TestTemplateCacheChainKeys(t *testing.T) {
+	first := MapLoader{"a.vm": `first`}
+	second := MapLoader{"a.vm": `second`, "b.vm": `#parse("a.vm")`}
+	key, _, err := ChainLoader{first, second}.Stat("a.vm")
+	require.NoError(t, err)
+	assert.Equal(t, "0:a.vm", key)
+	key, _, err = ChainLoader{first, second}.Stat("b.vm")
+	require.NoError(t, err)
+	assert.Equal(t, "1:b.vm", key)
+	_, _, err = ChainLoader{first, second}.Stat("c.vm")
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+}
+
+func benchmarkParseRows(b *testing.B, cache *TemplateCache) {
+	loader := MapLoader{
+		"lib.vm": `#macro(cell $v)<td>$v</td>#end`,
+		"row.vm": `<tr>#cell($i)#cell($foreach.count)</tr>`,
+	}
+	tmpl, err := ParseWithLoader(`#foreach($i in [1..500])#parse("row.vm")#end`, loader, "lib.vm")
+	require.NoError(b, err)
+	tmpl.WithTemplateCache(cache)
+	var buf bytes.Buffer
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := tmpl.Execute(&buf, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseCached(b *testing.B) {
+	benchmarkParseRows(b, NewTemplateCache(false))
+}
+
+func BenchmarkParseCachedModTime(b *testing.B) {
+	benchmarkParseRows(b, NewTemplateCache(true))
+}
+
+func BenchmarkParseUncached(b *testing.B) {
+	benchmarkParseRows(b, nil)
+}
@@ -0,0 +1,11 @@
+module github.com/iron-s/go-vtl/cmd/vtl
+
+go 1.13
+
+require (
+	github.com/iron-s/go-vtl v0.0.0
+	github.com/stretchr/testify v1.4.0
+	gopkg.in/yaml.v2 v2.2.4
+)
+
+replace github.com/iron-s/go-vtl => ../..
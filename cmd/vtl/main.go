@@ -0,0 +1,246 @@
+// Command vtl renders Velocity templates.
+//
+// Usage:
+//
+//	vtl [flags] template.vm
+//	vtl --check [flags] template.vm...
+//
+// Context data is read from JSON or YAML files given with -data, later files
+// override earlier ones, and from -var name=value flags, which override the
+// files. Values of -var which are valid JSON are decoded, otherwise they are
+// strings.
+//
+// With --strict-exit-codes the exit code tells the kind of the failure: 2 for
+// invalid usage, 3 for syntax errors, 4 for execution errors and 5 for
+// failures to read data or write output. Otherwise any failure exits with 1.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	govtl "github.com/iron-s/go-vtl"
+	"github.com/iron-s/go-vtl/tools"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	exitOK = iota
+	exitFailure
+	exitUsage
+	exitSyntax
+	exitExecute
+	exitIO
+)
+
+// listFlag collects values of the repeated flag
+type listFlag []string
+
+func (l *listFlag) String() string { return strings.Join(*l, ",") }
+
+func (l *listFlag) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// failure is an error with the exit code used in strict mode
+type failure struct {
+	code int
+	err  error
+}
+
+func (f *failure) Error() string { return f.err.Error() }
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("vtl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var (
+		data, vars listFlag
+		root       = fs.String("root", ".", "root `dir` for #include, #parse and the macro library")
+		lib        = fs.String("lib", "", "macro library `file`, relative to the root")
+		out        = fs.String("o", "", "write output to `file` instead of stdout")
+		check      = fs.Bool("check", false, "only parse templates and report syntax errors")
+		strict     = fs.Bool("strict-exit-codes", false, "use distinct exit codes for different failures")
+		withTools  = fs.Bool("tools", false, "make $math, $number, $date, $esc and $display available")
+	)
+	fs.Var(&data, "data", "JSON or YAML `file` with context data, could be repeated")
+	fs.Var(&vars, "var", "context variable as `name=value`, could be repeated")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: vtl [flags] template.vm\n       vtl --check [flags] template.vm...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	var err error
+	switch {
+	case fs.NArg() == 0 || !*check && fs.NArg() > 1:
+		fs.Usage()
+		return exitUsage
+	case *check:
+		err = checkAll(fs.Args(), *root, *lib, stderr)
+	default:
+		err = render(fs.Arg(0), *root, *lib, data, vars, *withTools, *out, stdout)
+	}
+	if err == nil {
+		return exitOK
+	}
+	var f *failure
+	if errors.As(err, &f) && *strict {
+		fmt.Fprintln(stderr, f.err)
+		return f.code
+	}
+	fmt.Fprintln(stderr, err)
+	return exitFailure
+}
+
+func parse(name, root, lib string) (*govtl.Template, error) {
+	t, err := govtl.ParseFile(name, root, lib)
+	var syntax govtl.SyntaxErrors
+	switch {
+	case errors.As(err, &syntax):
+		return nil, &failure{exitSyntax, err}
+	case err != nil:
+		return nil, &failure{exitIO, err}
+	}
+	return t, nil
+}
+
+// checkAll parses all templates reporting every failure, the error is the
+// first one
+func checkAll(names []string, root, lib string, stderr io.Writer) error {
+	var first error
+	for _, name := range names {
+		if _, err := parse(name, root, lib); err != nil {
+			if first == nil {
+				first = err
+				continue
+			}
+			fmt.Fprintln(stderr, err)
+		}
+	}
+	return first
+}
+
+func render(name, root, lib string, dataFiles, vars []string, withTools bool, out string, stdout io.Writer) error {
+	t, err := parse(name, root, lib)
+	if err != nil {
+		return err
+	}
+	data, err := loadData(dataFiles, vars)
+	if err != nil {
+		return &failure{exitIO, err}
+	}
+	if withTools {
+		data = tools.Merge(data, tools.New())
+	}
+	// output file is written only if rendering succeeds
+	var b bytes.Buffer
+	w := stdout
+	if out != "" {
+		w = &b
+	}
+	if err := t.Execute(w, data); err != nil {
+		return &failure{exitExecute, err}
+	}
+	if out != "" {
+		if err := ioutil.WriteFile(out, b.Bytes(), 0644); err != nil {
+			return &failure{exitIO, err}
+		}
+	}
+	return nil
+}
+
+func loadData(files, vars []string) (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var v interface{}
+		switch strings.ToLower(filepath.Ext(f)) {
+		case ".json":
+			d := json.NewDecoder(bytes.NewReader(b))
+			d.UseNumber()
+			err = d.Decode(&v)
+		case ".yaml", ".yml":
+			err = yaml.Unmarshal(b, &v)
+		default:
+			return nil, fmt.Errorf("%s: unknown data format, expected .json, .yaml or .yml", f)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+		m, ok := normalize(v).(map[string]interface{})
+		if !ok && v != nil {
+			return nil, fmt.Errorf("%s: data must be an object", f)
+		}
+		for k, v := range m {
+			data[k] = v
+		}
+	}
+	for _, kv := range vars {
+		i := strings.IndexByte(kv, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("invalid -var %q, expected name=value", kv)
+		}
+		var v interface{} = kv[i+1:]
+		d := json.NewDecoder(strings.NewReader(kv[i+1:]))
+		d.UseNumber()
+		var decoded interface{}
+		if d.Decode(&decoded) == nil && !d.More() {
+			v = normalize(decoded)
+		}
+		data[kv[:i]] = v
+	}
+	return data, nil
+}
+
+// normalize converts decoded data to the types templates work best with:
+// maps with string keys and integer numbers as int64
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, vv := range v {
+			v[k] = normalize(vv)
+		}
+		return v
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, vv := range v {
+			m[fmt.Sprint(k)] = normalize(vv)
+		}
+		return m
+	case []interface{}:
+		for i := range v {
+			v[i] = normalize(v[i])
+		}
+		return v
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case int:
+		return int64(v)
+	}
+	return v
+}
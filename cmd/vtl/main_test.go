@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vtl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"hello.vm":   `#greet($name) $count #foreach($i in $items)$i.name#end`,
+		"lib.vm":     `#macro(greet $who)Hello, $who!#end`,
+		"vars.vm":    `$name $n $flag $list $obj.a`,
+		"include.vm": `#parse('part.vm')`,
+		"part.vm":    `part of $name`,
+		"tools.vm":   `$number.integer(1234.5)`,
+		"bad.vm":     `#if($x`,
+		"bad2.vm":    `#foreach(`,
+		"fail.vm":    `before $undefined after`,
+		"data.json":  `{"name": "json", "count": 2, "items": [{"name": "a"}, {"name": "b"}]}`,
+		"data.yaml":  "name: yaml\nitems:\n  - name: c\n",
+		"data.txt":   `name=x`,
+		"list.json":  `[1, 2]`,
+	}
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	p := func(name string) string { return filepath.Join(dir, name) }
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		strict int
+		stdout string
+		stderr string
+	}{
+		{"json", []string{"-root", dir, "-lib", "lib.vm", "-data", p("data.json"), p("hello.vm")}, 0, 0, "Hello, json! 2 ab", ""},
+		{"yaml overrides json", []string{"-root", dir, "-lib", "lib.vm", "-data", p("data.json"), "-data", p("data.yaml"), p("hello.vm")}, 0, 0, "Hello, yaml! 2 c", ""},
+		{"vars", []string{"-var", "name=x", "-var", "n=5", "-var", "flag=true", "-var", "list=[1,2]", "-var", `obj={"a":"b"}`, p("vars.vm")}, 0, 0, "x 5 true [1, 2] b", ""},
+		{"vars override data", []string{"-root", dir, "-lib", "lib.vm", "-data", p("data.json"), "-var", "name=var", "-var", "count=1 2", p("hello.vm")}, 0, 0, "Hello, var! 1 2 ab", ""},
+		{"parse from root", []string{"-root", dir, "-var", "name=x", p("include.vm")}, 0, 0, "part of x", ""},
+		{"tools", []string{"-tools", p("tools.vm")}, 0, 0, "1,234", ""},
+		{"check", []string{"-check", "-root", dir, "-lib", "lib.vm", p("hello.vm"), p("vars.vm")}, 0, 0, "", ""},
+		{"check errors", []string{"--check", p("bad.vm"), p("hello.vm"), p("bad2.vm")}, 1, 3, "", "bad.vm: unexpected $end"},
+		{"execution error", []string{p("fail.vm")}, 1, 4, "before ", "undefined var $undefined"},
+		{"missing template", []string{p("missing.vm")}, 1, 5, "", "no such file"},
+		{"unknown data format", []string{"-data", p("data.txt"), p("vars.vm")}, 1, 5, "", "unknown data format"},
+		{"data is not an object", []string{"-data", p("list.json"), p("vars.vm")}, 1, 5, "", "data must be an object"},
+		{"invalid var", []string{"-var", "x", p("vars.vm")}, 1, 5, "", `invalid -var "x"`},
+		{"no template", []string{}, 2, 2, "", "usage: vtl"},
+		{"too many templates", []string{p("vars.vm"), p("hello.vm")}, 2, 2, "", "usage: vtl"},
+		{"unknown flag", []string{"-x", p("vars.vm")}, 2, 2, "", "flag provided but not defined"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, strict := range []bool{false, true} {
+				args := test.args
+				code := test.code
+				if strict {
+					args = append([]string{"--strict-exit-codes"}, args...)
+					code = test.strict
+				}
+				var stdout, stderr bytes.Buffer
+				assert.Equal(t, code, run(args, &stdout, &stderr), "strict %v: %s", strict, stderr.String())
+				assert.Equal(t, test.stdout, stdout.String())
+				assert.Contains(t, stderr.String(), test.stderr)
+			}
+		})
+	}
+}
+
+func TestRunCheckReportsAll(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vtl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"a.vm", "b.vm"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(`#set(`), 0644))
+	}
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 3, run([]string{"--check", "--strict-exit-codes", filepath.Join(dir, "a.vm"), filepath.Join(dir, "b.vm")}, &stdout, &stderr))
+	assert.Equal(t, 2, strings.Count(stderr.String(), "unexpected"), stderr.String())
+}
+
+func TestRunOutputFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vtl")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	tmpl, out := filepath.Join(dir, "t.vm"), filepath.Join(dir, "out.txt")
+	require.NoError(t, ioutil.WriteFile(tmpl, []byte(`Hello, $name!`), 0644))
+	var stdout, stderr bytes.Buffer
+	require.Equal(t, 0, run([]string{"-o", out, "-var", "name=file", tmpl}, &stdout, &stderr), stderr.String())
+	assert.Empty(t, stdout.String())
+	b, err := ioutil.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, file!", string(b))
+
+	// failed rendering keeps the previous output
+	require.Equal(t, 1, run([]string{"-o", out, tmpl}, &stdout, &stderr))
+	b, err = ioutil.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, file!", string(b))
+}
@@ -0,0 +1,212 @@
+package govtl
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// convertValue converts value of the template to the type t of the argument.
+// Lists and maps are converted deeply to slices, arrays, maps and structs,
+// fields of which are set from map entries. Numbers are converted when they
+// fit into t
+func convertValue(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	for v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		switch t.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return reflect.Zero(t), nil
+		}
+		return Nil, fmt.Errorf("not assignable nil -> %s", t)
+	}
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+	if n := native(v); n.IsValid() {
+		// lists and maps of the template are passed as Go values they
+		// wrap
+		if v = n; v.Type().AssignableTo(t) {
+			return v, nil
+		}
+	}
+	switch k := v.Kind(); t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if k == reflect.Slice || k == reflect.Array {
+			return convertList(v, t)
+		}
+	case reflect.Map:
+		if k == reflect.Map {
+			return convertMap(v, t)
+		}
+	case reflect.Struct:
+		if k == reflect.Map {
+			return convertStruct(v, t)
+		}
+	case reflect.Ptr:
+		if k == reflect.Map && t.Elem().Kind() == reflect.Struct {
+			s, err := convertStruct(v, t.Elem())
+			if err != nil {
+				return Nil, err
+			}
+			p := reflect.New(t.Elem())
+			p.Elem().Set(s)
+			return p, nil
+		}
+	}
+	if isNumber(v) && isNumber(reflect.Zero(t)) {
+		return convertNumber(v, t)
+	}
+	if v.Type().ConvertibleTo(t) && !(isInt(v) && t.Kind() == reflect.String) {
+		// Go converts integers to strings as runes
+		return v.Convert(t), nil
+	}
+	return Nil, fmt.Errorf("not assignable %s -> %s", v.Type(), t)
+}
+
+func convertList(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	var r reflect.Value
+	if t.Kind() == reflect.Array {
+		if v.Len() != t.Len() {
+			return Nil, fmt.Errorf("cannot use %d elements as %s", v.Len(), t)
+		}
+		r = reflect.New(t).Elem()
+	} else {
+		r = reflect.MakeSlice(t, v.Len(), v.Len())
+	}
+	for i := 0; i < v.Len(); i++ {
+		e, err := convertValue(v.Index(i), t.Elem())
+		if err != nil {
+			return Nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		r.Index(i).Set(e)
+	}
+	return r, nil
+}
+
+func convertMap(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	r := reflect.MakeMapWithSize(t, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		k, err := convertValue(iter.Key(), t.Key())
+		if err != nil {
+			return Nil, fmt.Errorf("key %v: %w", iter.Key(), err)
+		}
+		e, err := convertValue(iter.Value(), t.Elem())
+		if err != nil {
+			return Nil, fmt.Errorf("key %v: %w", iter.Key(), err)
+		}
+		r.SetMapIndex(k, e)
+	}
+	return r, nil
+}
+
+// convertStruct sets fields of the struct from map entries, keys are looked
+// up as properties, i.e. the first letter is uppercased
+func convertStruct(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	r := reflect.New(t).Elem()
+	iter := v.MapRange()
+	for iter.Next() {
+		key := iter.Key()
+		for key.Kind() == reflect.Interface {
+			key = key.Elem()
+		}
+		if key.Kind() != reflect.String {
+			return Nil, fmt.Errorf("key %v: not a field name of %s", key, t)
+		}
+		name := key.String()
+		field, ok := t.FieldByName(ucFirst(name))
+		if !ok || field.PkgPath != "" {
+			return Nil, fmt.Errorf("no field %s in %s", name, t)
+		}
+		e, err := convertValue(iter.Value(), field.Type)
+		if err != nil {
+			return Nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		r.FieldByIndex(field.Index).Set(e)
+	}
+	return r, nil
+}
+
+// convertNumber converts number v to numeric type t if the value could be
+// represented in it
+func convertNumber(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	r := reflect.New(t).Elem()
+	kv, kt := basicKind(v), basicKind(r)
+	if kv == reflect.Float64 && kt != reflect.Float64 {
+		if f := v.Float(); f != math.Trunc(f) || math.IsInf(f, 0) {
+			return Nil, fmt.Errorf("%v is not an integer, cannot convert to %s", v, t)
+		}
+	}
+	overflows := false
+	switch kt {
+	case reflect.Int64:
+		switch kv {
+		case reflect.Int64:
+			overflows = r.OverflowInt(v.Int())
+		case reflect.Uint64:
+			overflows = v.Uint() > math.MaxInt64 || r.OverflowInt(int64(v.Uint()))
+		case reflect.Float64:
+			overflows = v.Float() < math.MinInt64 || v.Float() >= math.MaxInt64 || r.OverflowInt(int64(v.Float()))
+		}
+	case reflect.Uint64:
+		switch kv {
+		case reflect.Int64:
+			overflows = v.Int() < 0 || r.OverflowUint(uint64(v.Int()))
+		case reflect.Uint64:
+			overflows = r.OverflowUint(v.Uint())
+		case reflect.Float64:
+			overflows = v.Float() < 0 || v.Float() >= math.MaxUint64 || r.OverflowUint(uint64(v.Float()))
+		}
+	case reflect.Float64:
+		overflows = kv == reflect.Float64 && r.OverflowFloat(v.Float())
+	}
+	if overflows {
+		return Nil, fmt.Errorf("%v overflows %s", v, t)
+	}
+	return v.Convert(t), nil
+}
+
+// convertibleType mirrors convertValue for the type checker, element types of
+// lists and maps of the template are not known and are not checked
+func convertibleType(from, to reflect.Type) bool {
+	switch {
+	case from == nil || from.Kind() == reflect.Interface:
+		return true
+	case from.AssignableTo(to) || wrappedType(from).AssignableTo(to):
+		return true
+	}
+	switch from {
+	case listLitType, sliceType:
+		return to.Kind() == reflect.Slice || to.Kind() == reflect.Array
+	case mapLitType, mapType:
+		return to.Kind() == reflect.Map || to.Kind() == reflect.Struct ||
+			to.Kind() == reflect.Ptr && to.Elem().Kind() == reflect.Struct
+	}
+	fk := from.Kind()
+	switch to.Kind() {
+	case reflect.Slice, reflect.Array:
+		if fk == reflect.Slice || fk == reflect.Array {
+			return convertibleType(from.Elem(), to.Elem())
+		}
+	case reflect.Map:
+		if fk == reflect.Map {
+			return convertibleType(from.Key(), to.Key()) && convertibleType(from.Elem(), to.Elem())
+		}
+	case reflect.Struct:
+		if fk == reflect.Map {
+			return from.Key().Kind() == reflect.String || from.Key().Kind() == reflect.Interface
+		}
+	case reflect.Ptr:
+		if fk == reflect.Map && to.Elem().Kind() == reflect.Struct {
+			return convertibleType(from, to.Elem())
+		}
+	}
+	from = wrappedType(from)
+	isNum := func(t reflect.Type) bool { return isNumber(reflect.Zero(t)) }
+	if isNum(from) && isNum(to) {
+		return true
+	}
+	return from.ConvertibleTo(to) && !(isInt(reflect.Zero(from)) && to.Kind() == reflect.String)
+}
@@ -0,0 +1,118 @@
+package govtl
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type convPoint struct {
+	X, Y int
+}
+
+type convShape struct {
+	Name   string
+	Points []convPoint
+	Center *convPoint
+	Tags   map[string]bool
+	hidden int
+}
+
+func TestConvertValue(t *testing.T) {
+	list := func(v ...interface{}) *Slice { return &Slice{s: v} }
+	tests := []struct {
+		name      string
+		v         interface{}
+		typ       interface{}
+		expect    interface{}
+		expectErr string
+	}{
+		{"assignable", 1, 0, 1, ""},
+		{"string", Str("a"), "", "a", ""},
+		{"int literal", int64(3), uint8(0), uint8(3), ""},
+		{"float to int", 2.0, 0, 2, ""},
+		{"int to float", int64(2), float32(0), float32(2), ""},
+		{"overflow", int64(300), int8(0), nil, "300 overflows int8"},
+		{"negative to unsigned", int64(-1), uint(0), nil, "-1 overflows uint"},
+		{"fraction", 1.5, 0, nil, "1.5 is not an integer, cannot convert to int"},
+		{"int is not a string", int64(65), "", nil, "not assignable int64 -> string"},
+		{"nil", nil, []int{}, []int(nil), ""},
+		{"nil to int", nil, 0, nil, "not assignable nil -> int"},
+		{"list", list(Str("a"), Str("b")), []string{}, []string{"a", "b"}, ""},
+		{"nested list", list(list(int64(1)), list()), [][]int{}, [][]int{{1}, {}}, ""},
+		{"go slice", []int64{1, 2}, []int{}, []int{1, 2}, ""},
+		{"array", list(int64(1), int64(2)), [2]int{}, [2]int{1, 2}, ""},
+		{"array length", list(int64(1)), [2]int{}, nil, "cannot use 1 elements as [2]int"},
+		{"element", list(int64(1), Str("x")), []int{}, nil, "element 1: not assignable govtl.Str -> int"},
+		{"map", &Map{m: map[string]interface{}{"a": int64(1)}}, map[string]int{}, map[string]int{"a": 1}, ""},
+		{"map keys", &Map{m: map[interface{}]interface{}{int64(1): Str("a")}}, map[int]string{}, map[int]string{1: "a"}, ""},
+		{"map value", &Map{m: map[string]interface{}{"a": Str("x")}}, map[string]int{}, nil, "key a: not assignable govtl.Str -> int"},
+		{"struct",
+			&Map{m: map[string]interface{}{
+				"name":   Str("s"),
+				"points": list(&Map{m: map[string]interface{}{"x": int64(1), "y": int64(2)}}),
+				"center": &Map{m: map[string]interface{}{"x": int64(3)}},
+				"tags":   &Map{m: map[string]interface{}{"a": true}},
+			}},
+			convShape{},
+			convShape{Name: "s", Points: []convPoint{{1, 2}}, Center: &convPoint{X: 3}, Tags: map[string]bool{"a": true}}, ""},
+		{"pointer to struct", &Map{m: map[string]interface{}{"x": int64(1)}}, &convPoint{}, &convPoint{X: 1}, ""},
+		{"unknown field", &Map{m: map[string]interface{}{"z": int64(1)}}, convPoint{}, nil, "no field z in govtl.convPoint"},
+		{"unexported field", &Map{m: map[string]interface{}{"hidden": int64(1)}}, convShape{}, nil, "no field hidden in govtl.convShape"},
+		{"field", &Map{m: map[string]interface{}{"points": list(Str("p"))}}, convShape{},
+			nil, "field points: element 0: not assignable govtl.Str -> govtl.convPoint"},
+		{"not a map", list(), convPoint{}, nil, "not assignable []interface {} -> govtl.convPoint"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v, err := convertValue(reflect.ValueOf(test.v), reflect.TypeOf(test.typ))
+			if test.expectErr != "" {
+				assert.EqualError(t, err, test.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expect, v.Interface())
+		})
+	}
+}
+
+type convService struct{}
+
+func (convService) Join(s []string) string         { return s[0] + s[len(s)-1] }
+func (convService) Area(s convShape) int           { return len(s.Points) * s.Center.X }
+func (convService) Scale(p *convPoint, n int8) int { return p.X * int(n) }
+func (convService) Grid(g [][]int) int             { return g[1][0] }
+
+func TestExecuteConvertArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		tmpl      string
+		expect    string
+		expectErr string
+	}{
+		{"list of strings", `$s.join(['a', "$v", 'c'])`, "ac", ""},
+		{"struct from map", `$s.area({'points': [{'x': 1}, {'y': 2}], 'center': {'x': 3}})`, "6", ""},
+		{"pointer and number", `$s.scale({'x': 2}, 4)`, "8", ""},
+		{"nested", `$s.grid([[1], [2, 3]])`, "2", ""},
+		{"function", `$fn.sum(2.0, $n)`, "3", ""},
+		{"overflow", `$s.scale({'x': 2}, 1000)`, "", "arg 1: 1000 overflows int8"},
+		{"mismatch", `$s.area({'center': 1})`, "", "arg 0: field center: not assignable int64 -> *govtl.convPoint"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			require.NoError(t, err)
+			var b bytes.Buffer
+			err = tmpl.WithFuncs(testFuncs).Execute(&b, _m{"s": convService{}, "v": "b", "n": 1})
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, unposErr(err), test.expectErr)
+			}
+			assert.Equal(t, test.expect, b.String())
+		})
+	}
+}
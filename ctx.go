@@ -1,18 +1,59 @@
 package govtl
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
 
 type Ctx struct {
 	s map[string][]reflect.Value
+	*state
 
 	callDepth int
+	// done is checked for cancellation during execution
+	done context.Context
+	// name of the template being executed and the chain of calls leading to
+	// it
+	name   string
+	frames []Frame
+	// raw is set while a value is built from interpolated string, its
+	// references are escaped only when the value is output
+	raw bool
+}
+
+// state holds everything that changes during a single execution, so that
+// the same Template could be executed concurrently
+type state struct {
+	pos    Pos
+	macros map[string]*MacroNode
 }
 
 func NewContext() Ctx {
-	return Ctx{make(map[string][]reflect.Value), 0}
+	return Ctx{make(map[string][]reflect.Value), newState(), 0, context.Background(), "", nil, false}
+}
+
+func newState() *state {
+	return &state{macros: make(map[string]*MacroNode)}
+}
+
+// canceled returns an error if the execution was canceled or its deadline
+// passed
+func (ctx Ctx) canceled() error {
+	if err := ctx.done.Err(); err != nil {
+		return ctx.error(fmt.Errorf("execution canceled: %w", err))
+	}
+	return nil
+}
+
+// call returns context for executing a call at the current position of
+// template name
+func (ctx Ctx) call(call, name string) Ctx {
+	f := Frame{call, ctx.name, ctx.pos.line, ctx.pos.col}
+	ctx.frames = append(ctx.frames[:len(ctx.frames):len(ctx.frames)], f)
+	ctx.name = name
+	ctx.callDepth++
+	return ctx
 }
 
 func (ctx Ctx) Push(k string, v reflect.Value) int {
@@ -0,0 +1,211 @@
+package govtl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// ArgKind is the kind of the custom directive argument, argument values are
+// checked and converted according to it before the directive is rendered
+type ArgKind int
+
+const (
+	// ArgAny passes the value as is, nil for undefined references
+	ArgAny ArgKind = iota
+	// ArgString passes string
+	ArgString
+	// ArgInt passes int, integers which do not fit into int are rejected
+	ArgInt
+	// ArgNumber passes float64, both integers and floats are accepted
+	ArgNumber
+	// ArgBool passes bool
+	ArgBool
+	// ArgList passes *Slice
+	ArgList
+	// ArgMap passes *Map
+	ArgMap
+)
+
+func (k ArgKind) String() string {
+	switch k {
+	case ArgAny:
+		return "any"
+	case ArgString:
+		return "string"
+	case ArgInt:
+		return "int"
+	case ArgNumber:
+		return "number"
+	case ArgBool:
+		return "bool"
+	case ArgList:
+		return "list"
+	case ArgMap:
+		return "map"
+	}
+	return "unknown"
+}
+
+// DirectiveSpec describes the shape of the custom directive
+type DirectiveSpec struct {
+	// Args are kinds of the arguments
+	Args []ArgKind
+	// Optional is the number of trailing arguments which could be omitted
+	Optional int
+	// Variadic allows any number of extra arguments of the last kind
+	Variadic bool
+	// Body is true if the directive has a body terminated by #end
+	Body bool
+}
+
+// Body renders the body of the directive to w, it could be called any
+// number of times. Body is nil for directives without body
+type Body func(w io.Writer) error
+
+// Directive is a custom directive called as #name(args) or, if it has a
+// body, as #name(args) body #end
+type Directive interface {
+	Spec() DirectiveSpec
+	// Render is called with arguments evaluated and converted according to
+	// the spec
+	Render(w io.Writer, args []interface{}, body Body) error
+}
+
+// Directives is a set of custom directives. Templates parsed with its Parse
+// methods know them as #name, as do templates they #parse and #evaluate
+type Directives struct {
+	m map[string]Directive
+}
+
+func NewDirectives() *Directives {
+	return &Directives{m: make(map[string]Directive)}
+}
+
+// Register makes directive d known as #name to templates parsed after the
+// call. It panics if name is not a valid identifier or is a name of the
+// builtin directive
+func (s *Directives) Register(name string, d Directive) *Directives {
+	for i, r := range name {
+		if !isIdent(r) && (i == 0 || !isNum(r)) {
+			panic(fmt.Sprintf("invalid directive name %q", name))
+		}
+	}
+	if name == "" {
+		panic("empty directive name")
+	}
+	if _, ok := directives[name]; ok {
+		panic(fmt.Sprintf("cannot redefine builtin directive #%s", name))
+	}
+	s.m[name] = d
+	return s
+}
+
+// Parse is like the package function Parse, but the template knows
+// directives of s
+func (s *Directives) Parse(vtl, root, lib string) (*Template, error) {
+	return s.ParseWithLoader(vtl, DirLoader{Root: root}, lib)
+}
+
+// ParseName is like the package function ParseName, but the template knows
+// directives of s
+func (s *Directives) ParseName(name string, loader Loader, lib string) (*Template, error) {
+	data, err := loader.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	return parseTemplate(name, string(data), loader, lib, s.snapshot())
+}
+
+// ParseWithLoader is like the package function ParseWithLoader, but the
+// template knows directives of s
+func (s *Directives) ParseWithLoader(vtl string, loader Loader, lib string) (*Template, error) {
+	return parseTemplate("", vtl, loader, lib, s.snapshot())
+}
+
+// snapshot returns a copy of directives, so later registrations do not
+// affect parsed templates
+func (s *Directives) snapshot() map[string]Directive {
+	m := make(map[string]Directive, len(s.m))
+	for k, v := range s.m {
+		m[k] = v
+	}
+	return m
+}
+
+// directiveCall builds the node for the custom directive call, checking the
+// number of arguments
+func directiveCall(l interface{}, tok Token, args []*OpNode, body []Node) Node {
+	lex := l.(*Lexer)
+	d := lex.directives[tok.literal]
+	spec := d.Spec()
+	min, max := len(spec.Args)-spec.Optional, len(spec.Args)
+	switch {
+	case len(args) < min:
+		lex.errorAt(tok.pos(), fmt.Sprintf("too few arguments for #%s, expected at least %d", tok.literal, min))
+	case len(args) > max && !spec.Variadic:
+		lex.errorAt(tok.pos(), fmt.Sprintf("too many arguments for #%s, expected at most %d", tok.literal, max))
+	}
+	return &DirectiveNode{Name: tok.literal, Args: args, Body: body, Pos: tok.pos(), d: d}
+}
+
+func (t *Template) renderDirective(w io.Writer, n *DirectiveNode, ctx Ctx) (stop bool, err error) {
+	spec := n.d.Spec()
+	args := make([]interface{}, len(n.Args))
+	for i, a := range n.Args {
+		v, err := t.eval(a, ctx, true)
+		if err != nil && !errors.As(err, &undefinedError{}) && !errors.As(err, &nilError{}) {
+			return true, err
+		}
+		kind := ArgAny
+		if len(spec.Args) > 0 {
+			kind = spec.Args[len(spec.Args)-1]
+		}
+		if i < len(spec.Args) {
+			kind = spec.Args[i]
+		}
+		if args[i], err = convertArg(v, kind); err != nil {
+			return true, fmt.Errorf("argument %d of #%s: %w", i+1, n.Name, err)
+		}
+	}
+	var body Body
+	if spec.Body {
+		// after #stop in the body nothing is rendered anymore
+		body = func(w io.Writer) error {
+			if stop {
+				return nil
+			}
+			s, err := t._execute(w, n.Body, ctx)
+			stop = stop || s
+			return err
+		}
+	}
+	if err := n.d.Render(w, args, body); err != nil {
+		return true, err
+	}
+	return stop, nil
+}
+
+func convertArg(v reflect.Value, kind ArgKind) (interface{}, error) {
+	switch {
+	case kind == ArgAny && !v.IsValid():
+		return nil, nil
+	case !v.IsValid():
+	case kind == ArgAny, kind == ArgList && v.Type() == sliceType, kind == ArgMap && v.Type() == mapType:
+		return v.Interface(), nil
+	case kind == ArgString && v.Kind() == reflect.String:
+		return v.String(), nil
+	case kind == ArgInt && isInt(v):
+		n, err := convertNumber(v, reflect.TypeOf(0))
+		if err != nil {
+			return nil, err
+		}
+		return int(n.Int()), nil
+	case kind == ArgNumber && isNumber(v):
+		return toFloat(v), nil
+	case kind == ArgBool && v.Kind() == reflect.Bool:
+		return v.Bool(), nil
+	}
+	return nil, fmt.Errorf("expected %s, got %s", kind, getKind(v))
+}
@@ -0,0 +1,171 @@
+package govtl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDirective struct {
+	spec   DirectiveSpec
+	render func(w io.Writer, args []interface{}, body Body) error
+}
+
+func (d testDirective) Spec() DirectiveSpec { return d.spec }
+
+func (d testDirective) Render(w io.Writer, args []interface{}, body Body) error {
+	return d.render(w, args, body)
+}
+
+// testDirectives returns directives used by tests
+func testDirectives() *Directives {
+	return NewDirectives().Register("upper", testDirective{DirectiveSpec{Body: true},
+		func(w io.Writer, args []interface{}, body Body) error {
+			var b bytes.Buffer
+			if err := body(&b); err != nil {
+				return err
+			}
+			_, err := io.WriteString(w, strings.ToUpper(b.String()))
+			return err
+		}}).Register("repeat", testDirective{DirectiveSpec{Args: []ArgKind{ArgInt, ArgString}, Optional: 1, Body: true},
+		func(w io.Writer, args []interface{}, body Body) error {
+			for i := 0; i < args[0].(int); i++ {
+				if i > 0 && len(args) > 1 {
+					io.WriteString(w, args[1].(string))
+				}
+				if err := body(w); err != nil {
+					return err
+				}
+			}
+			return nil
+		}}).Register("args", testDirective{DirectiveSpec{Args: []ArgKind{ArgAny}, Variadic: true},
+		func(w io.Writer, args []interface{}, body Body) error {
+			for _, a := range args {
+				fmt.Fprintf(w, "%T;", a)
+			}
+			return nil
+		}}).Register("kinds", testDirective{DirectiveSpec{Args: []ArgKind{ArgString, ArgInt, ArgNumber, ArgBool, ArgList, ArgMap}},
+		func(w io.Writer, args []interface{}, body Body) error {
+			fmt.Fprintf(w, "%v %v %v %v %T %T", args...)
+			return nil
+		}}).Register("fail", testDirective{DirectiveSpec{},
+		func(w io.Writer, args []interface{}, body Body) error {
+			return errors.New("failed")
+		}})
+}
+
+func TestDirective(t *testing.T) {
+	tests := []struct {
+		name      string
+		tmpl      string
+		expect    string
+		expectErr string
+	}{
+		{"body", `#upper()hello $name#end!`, "HELLO WORLD!", ""},
+		{"curly", `#{upper}()a#{end}b`, "Ab", ""},
+		{"args", `#repeat(3, '-')x#end`, "x-x-x", ""},
+		{"optional arg", `#repeat(2)x#end`, "xx", ""},
+		{"nested", `#repeat(2)#upper()#repeat($n)a#end#end#end`, "AAAA", ""},
+		{"variadic", `#args(1, 'a', [1], {}, $undefined, $name)`, "int64;govtl.Str;*govtl.Slice;*govtl.Map;<nil>;govtl.Str;", ""},
+		{"kinds", `#kinds('s', 1, 2, true, [1], {'a': 1})`, "s 1 2 true *govtl.Slice *govtl.Map", ""},
+		{"int for number", `#kinds('s', 1, 2.5, true, [], {})`, "s 1 2.5 true *govtl.Slice *govtl.Map", ""},
+		{"lines", "#repeat(2)\n  x\n#end\n", "  x\n  x\n", ""},
+		{"not registered", `#unknown()`, "#unknown()", ""},
+		{"evaluate", `#evaluate('#upper()$name#end')`, "WORLD", ""},
+		{"stop in body", `#repeat(2)a#stop#end b`, "a", ""},
+		{"negative int", `#kinds('s', $neg, $neg, true, [], {})#repeat($neg)x#end`, "s -3 -3 true *govtl.Slice *govtl.Map", ""},
+		{"unsigned int", `#repeat($small)x#end`, "xx", ""},
+		{"int overflow", `#repeat($big)x#end`, "", "argument 1 of #repeat: 18446744073709551615 overflows int at line 1, column 1"},
+		{"wrong kind", `#repeat('a')x#end`, "", "argument 1 of #repeat: expected int, got string at line 1, column 1"},
+		{"render error", `a #fail()`, "a ", "failed at line 1, column 3"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := testDirectives().Parse(test.tmpl, "", "")
+			require.NoError(t, err)
+			var b bytes.Buffer
+			err = tmpl.Execute(&b, _m{"name": "world", "n": 2, "neg": -3, "small": uint8(2), "big": uint64(math.MaxUint64)})
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectErr)
+			}
+			assert.Equal(t, test.expect, b.String())
+		})
+	}
+}
+
+func TestDirectiveParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		tmpl   string
+		expect []string
+	}{
+		{"too few", `ab #repeat()x#end`, []string{"too few arguments for #repeat, expected at least 1: line 1, column 4"}},
+		{"too many", `#repeat(1, 'a', 2)x#end`, []string{"too many arguments for #repeat, expected at most 2: line 1, column 1"}},
+		{"no body", `#kinds()x#end`, []string{"too few arguments for #kinds, expected at least 6: line 1, column 1", "unexpected END"}},
+		{"unterminated body", `#upper()x`, []string{"unexpected $end"}},
+		{"macro with the same name", "#macro(upper)x#end\n#macro(fail $a)#end", []string{
+			"macro #upper conflicts with custom directive: line 1, column 8",
+			"macro #fail conflicts with custom directive: line 2, column 8",
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := testDirectives().Parse(test.tmpl, "", "")
+			var errs SyntaxErrors
+			require.True(t, errors.As(err, &errs), "%v", err)
+			require.Len(t, errs, len(test.expect))
+			for i := range errs {
+				assert.True(t, strings.HasPrefix(errs[i].Error(), test.expect[i]), "%v", errs[i])
+			}
+		})
+	}
+}
+
+func TestDirectivesRegister(t *testing.T) {
+	d := testDirective{}
+	s := NewDirectives()
+	assert.PanicsWithValue(t, "cannot redefine builtin directive #foreach", func() { s.Register("foreach", d) })
+	assert.PanicsWithValue(t, `invalid directive name "1a"`, func() { s.Register("1a", d) })
+	assert.PanicsWithValue(t, `invalid directive name "a-b"`, func() { s.Register("a-b", d) })
+	assert.Panics(t, func() { s.Register("", d) })
+}
+
+func TestDirectivesScope(t *testing.T) {
+	loader := MapLoader{
+		"lib.vm":  "#macro(upper)lib#end",
+		"page.vm": "#args($x)",
+	}
+	// directives are known only to templates parsed with them
+	tmpl, err := ParseWithLoader(`#upper()#parse('page.vm')`, loader, "lib.vm")
+	require.NoError(t, err)
+	var b bytes.Buffer
+	require.NoError(t, tmpl.Execute(&b, _m{"x": "x"}))
+	assert.Equal(t, "lib#args(x)", b.String())
+
+	_, err = testDirectives().ParseWithLoader(`#upper()#end`, loader, "lib.vm")
+	assert.EqualError(t, err, "lib.vm: macro #upper conflicts with custom directive: line 1, column 8 (#macro(upper|)lib#end)")
+
+	tmpl, err = testDirectives().ParseWithLoader(`#upper()#parse('page.vm')#end`, loader, "")
+	require.NoError(t, err)
+	b.Reset()
+	require.NoError(t, tmpl.Execute(&b, _m{"x": "x"}))
+	assert.Equal(t, "GOVTL.STR;", b.String())
+
+	// registration after parse does not change the template
+	s := NewDirectives()
+	tmpl, err = s.Parse(`#args()`, "", "")
+	require.NoError(t, err)
+	s.Register("args", testDirective{render: func(w io.Writer, args []interface{}, body Body) error { return nil }})
+	b.Reset()
+	require.NoError(t, tmpl.Execute(&b, nil))
+	assert.Equal(t, "#args()", b.String())
+}
@@ -0,0 +1,85 @@
+package govtl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error is returned by Execute when the template fails. It holds the position
+// of the failed node and the chain of #parse and #evaluate directives and
+// macro calls which led to it
+type Error struct {
+	// Name of the template, empty for the template parsed from string
+	Name   string
+	Line   int
+	Column int
+	// Frames is the call chain, the innermost call first
+	Frames []Frame
+	Err    error
+}
+
+// Frame is a single #parse, #evaluate or macro call
+type Frame struct {
+	// Call is #parse, #evaluate or the name of the macro prefixed with #
+	Call   string
+	Name   string
+	Line   int
+	Column int
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	if e.Name != "" {
+		b.WriteString(e.Name)
+		b.WriteString(": ")
+	}
+	fmt.Fprintf(&b, "%v at line %d, column %d", e.Err, e.Line, e.Column)
+	return b.String()
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+func (f Frame) String() string {
+	name := f.Name
+	if name == "" {
+		name = "<template>"
+	}
+	return fmt.Sprintf("%s at %s:%d:%d", f.Call, name, f.Line, f.Column)
+}
+
+// SyntaxError describes a single error found while parsing the template
+type SyntaxError struct {
+	// Name of the template, empty for the template parsed from string
+	Name   string
+	Line   int
+	Column int
+	// Unexpected is the token found at the position, empty if unknown
+	Unexpected string
+	// Expected lists tokens and grammar rules valid at the position
+	Expected []string
+
+	msg           string
+	before, after string
+}
+
+func (e *SyntaxError) Error() string {
+	var b strings.Builder
+	if e.Name != "" {
+		b.WriteString(e.Name)
+		b.WriteString(": ")
+	}
+	fmt.Fprintf(&b, "%s: line %d, column %d (%s|%s)", e.msg, e.Line, e.Column, e.before, e.after)
+	return b.String()
+}
+
+// SyntaxErrors is returned by Parse functions, it holds all errors found in
+// the template
+type SyntaxErrors []*SyntaxError
+
+func (e SyntaxErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i := range e {
+		msgs[i] = e[i].Error()
+	}
+	return strings.Join(msgs, "\n")
+}
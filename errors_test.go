@@ -0,0 +1,80 @@
+package govtl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	loader := MapLoader{
+		"lib.vm":   "#macro(row $v)\n  <td>$v.missing</td>\n#end",
+		"page.vm":  "<table>\n#parse('rows.vm')\n</table>",
+		"rows.vm":  "#foreach($i in [1])\n  #row($i)\n#end",
+		"local.vm": "#macro(cell)\n $nope#end\n#cell()",
+		"body.vm":  "#macro(wrap)[$bodyContent]#end\n#@wrap()\n  $undefined\n#end",
+		"plain.vm": "\n\t$undefined",
+	}
+	tests := []struct {
+		name   string
+		tmpl   string
+		expect *Error
+		msg    string
+	}{
+		{"named template", "plain.vm",
+			&Error{Name: "plain.vm", Line: 2, Column: 2, Frames: []Frame{}},
+			"plain.vm: undefined var $undefined at line 2, column 2"},
+		{"macro from library inside parse", "page.vm",
+			&Error{Name: "lib.vm", Line: 2, Column: 7, Frames: []Frame{
+				{"#row", "rows.vm", 2, 3},
+				{"#parse", "page.vm", 2, 1},
+			}},
+			"lib.vm: cannot get property missing of int64 value at line 2, column 7"},
+		{"macro defined in template", "local.vm",
+			&Error{Name: "local.vm", Line: 2, Column: 2, Frames: []Frame{
+				{"#cell", "local.vm", 3, 1},
+			}},
+			"local.vm: undefined var $nope at line 2, column 2"},
+		{"block macro body", "body.vm",
+			&Error{Name: "body.vm", Line: 3, Column: 3, Frames: []Frame{
+				{"#wrap", "body.vm", 2, 1},
+			}},
+			"body.vm: undefined var $undefined at line 3, column 3"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := ParseName(test.tmpl, loader, "lib.vm")
+			require.NoError(t, err)
+			var b bytes.Buffer
+			err = tmpl.Execute(&b, nil)
+			var e *Error
+			if assert.True(t, errors.As(err, &e)) {
+				assert.Equal(t, test.msg, err.Error())
+				e.Err = nil
+				assert.Equal(t, test.expect, e)
+			}
+		})
+	}
+}
+
+func TestErrorUnparsed(t *testing.T) {
+	tmpl, err := Parse("ok\n  $x", "", "")
+	require.NoError(t, err)
+	err = tmpl.Execute(&bytes.Buffer{}, nil)
+	var e *Error
+	if assert.True(t, errors.As(err, &e)) {
+		assert.Equal(t, "", e.Name)
+		assert.Equal(t, 2, e.Line)
+		assert.Equal(t, 3, e.Column)
+		assert.Empty(t, e.Frames)
+		assert.EqualError(t, err, "undefined var $x at line 2, column 3")
+	}
+}
+
+func TestFrameString(t *testing.T) {
+	assert.Equal(t, "#parse at page.vm:2:1", Frame{"#parse", "page.vm", 2, 1}.String())
+	assert.Equal(t, "#row at <template>:1:5", Frame{"#row", "", 1, 5}.String())
+}
@@ -0,0 +1,60 @@
+package govtl
+
+import (
+	"html"
+	"net/url"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// EscapeMode defines how values of references are escaped on output
+type EscapeMode int
+
+const (
+	// EscapeNone writes values as is
+	EscapeNone EscapeMode = iota
+	// EscapeHTML escapes values for HTML text and quoted attributes
+	EscapeHTML
+	// EscapeXML escapes values for XML text and attributes
+	EscapeXML
+	// EscapeJS escapes values for JavaScript string literals
+	EscapeJS
+	// EscapeURL escapes values for URL path segments and query components
+	EscapeURL
+)
+
+// SafeString is trusted content which is never escaped
+type SafeString string
+
+var safeStringType = reflect.TypeOf(SafeString(""))
+
+var xmlReplacer = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&apos;",
+)
+
+// Escape escapes s according to the mode
+func (m EscapeMode) Escape(s string) string {
+	switch m {
+	case EscapeHTML:
+		return html.EscapeString(s)
+	case EscapeXML:
+		return xmlReplacer.Replace(s)
+	case EscapeJS:
+		return template.JSEscapeString(s)
+	case EscapeURL:
+		return strings.Replace(url.QueryEscape(s), "+", "%20", -1)
+	}
+	return s
+}
+
+// WithEscape sets escaping mode for the values of references printed by the
+// template, values of SafeString type are printed as is
+func (t *Template) WithEscape(m EscapeMode) *Template {
+	t.escape = m
+	return t
+}
@@ -0,0 +1,74 @@
+package govtl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscape(t *testing.T) {
+	data := _m{
+		"text": `<a href="x?a=1&b='2'">Tom & Jerry</a>`,
+		"safe": SafeString("<b>bold</b>"),
+		"list": []string{"<i>", "&"},
+		"num":  42,
+		"y":    "<",
+	}
+	tests := []struct {
+		name   string
+		mode   EscapeMode
+		tmpl   string
+		expect string
+	}{
+		{"none", EscapeNone, `$text`,
+			`<a href="x?a=1&b='2'">Tom & Jerry</a>`},
+		{"html", EscapeHTML, `<p>$text</p>`,
+			`<p>&lt;a href=&#34;x?a=1&amp;b=&#39;2&#39;&#34;&gt;Tom &amp; Jerry&lt;/a&gt;</p>`},
+		{"xml", EscapeXML, `<v>$text</v>`,
+			`<v>&lt;a href=&quot;x?a=1&amp;b=&apos;2&apos;&quot;&gt;Tom &amp; Jerry&lt;/a&gt;</v>`},
+		{"js", EscapeJS, `var s = '$text';`,
+			`var s = '\u003Ca href\u003D\"x?a\u003D1\u0026b\u003D\'2\'\"\u003ETom \u0026 Jerry\u003C/a\u003E';`},
+		{"url", EscapeURL, `/search?q=$text`,
+			`/search?q=%3Ca%20href%3D%22x%3Fa%3D1%26b%3D%272%27%22%3ETom%20%26%20Jerry%3C%2Fa%3E`},
+		{"safe value", EscapeHTML, `$safe`,
+			`<b>bold</b>`},
+		{"collections", EscapeHTML, `$list`,
+			`[&lt;i&gt;, &amp;]`},
+		{"numbers", EscapeURL, `$num`,
+			`42`},
+		{"interpolated string", EscapeHTML, `#set($s = "<b>$num</b>")$s`,
+			`&lt;b&gt;42&lt;/b&gt;`},
+		{"interpolated value is escaped once", EscapeHTML, `#set($x = "a$y")$x $x.length() #if("$y" == "<")lt#end`,
+			`a&lt; 2 lt`},
+		{"define in interpolated string", EscapeHTML, `#define($d)<i>$y</i>#end#set($x = "$d")$x`,
+			`&lt;i&gt;&lt;&lt;/i&gt;`},
+		{"template text is not escaped", EscapeHTML, `<b>#if($num > 1)&#end</b>`,
+			`<b>&</b>`},
+		{"macro", EscapeHTML, `#macro(p $v)<p>$v</p>#end#p($text.substring(0, 2))`,
+			`<p>&lt;a</p>`},
+		{"define", EscapeHTML, `#define($d)<i>$list[1]</i>#end$d`,
+			`<i>&amp;</i>`},
+		{"block macro body", EscapeHTML, `#macro(card)<div>$bodyContent</div>#end#@card()<b>$list[0]</b>#end`,
+			`<div><b>&lt;i&gt;</b></div>`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			require.NoError(t, err)
+			var b bytes.Buffer
+			require.NoError(t, tmpl.WithEscape(test.mode).Execute(&b, data))
+			assert.Equal(t, test.expect, b.String())
+		})
+	}
+}
+
+func TestEscapeParse(t *testing.T) {
+	loader := MapLoader{"row.vm": `<td>$v</td>`}
+	tmpl, err := ParseWithLoader(`#foreach($v in ['<', '>'])#parse('row.vm')#end`, loader, "")
+	require.NoError(t, err)
+	var b bytes.Buffer
+	require.NoError(t, tmpl.WithEscape(EscapeHTML).Execute(&b, nil))
+	assert.Equal(t, `<td>&lt;</td><td>&gt;</td>`, b.String())
+}
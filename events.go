@@ -0,0 +1,155 @@
+package govtl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ReferenceInsertionHandler is called before the value of a reference is
+// written to the output, returned value is written instead
+type ReferenceInsertionHandler interface {
+	ReferenceInsert(ref string, value interface{}) interface{}
+}
+
+// InvalidReferenceHandler is called when a reference is undefined or has nil
+// value. If it returns true, returned value is used instead of the error
+type InvalidReferenceHandler interface {
+	InvalidReference(ref string, err error) (interface{}, bool)
+}
+
+// IncludeHandler is called for every #include and #parse, it returns the
+// name of the template to load, or an error to stop the execution. current is
+// the name of the template containing the directive
+type IncludeHandler interface {
+	Include(name, current, directive string) (string, error)
+}
+
+// MethodExceptionHandler is called when a method or a property getter
+// returns an error, method is the name of the property for getters. If it
+// returns nil error, returned value is used as a result of the call
+type MethodExceptionHandler interface {
+	MethodException(receiver interface{}, method string, err error) (interface{}, error)
+}
+
+type eventHandlers struct {
+	insert  []ReferenceInsertionHandler
+	invalid []InvalidReferenceHandler
+	include []IncludeHandler
+	method  []MethodExceptionHandler
+}
+
+// WithEventHandler registers h for all handler interfaces it implements.
+// Handlers are called in the order of registration. It panics if h
+// implements none of them
+func (t *Template) WithEventHandler(h interface{}) *Template {
+	var ok bool
+	if hh, is := h.(ReferenceInsertionHandler); is {
+		t.events.insert = append(t.events.insert, hh)
+		ok = true
+	}
+	if hh, is := h.(InvalidReferenceHandler); is {
+		t.events.invalid = append(t.events.invalid, hh)
+		ok = true
+	}
+	if hh, is := h.(IncludeHandler); is {
+		t.events.include = append(t.events.include, hh)
+		ok = true
+	}
+	if hh, is := h.(MethodExceptionHandler); is {
+		t.events.method = append(t.events.method, hh)
+		ok = true
+	}
+	if !ok {
+		panic(fmt.Sprintf("%T is not an event handler", h))
+	}
+	return t
+}
+
+func (t *Template) referenceInsert(n *VarNode, v reflect.Value) reflect.Value {
+	if len(t.events.insert) == 0 {
+		return v
+	}
+	ref := refString(n)
+	var val interface{}
+	if v.IsValid() {
+		val = v.Interface()
+	}
+	for _, h := range t.events.insert {
+		val = h.ReferenceInsert(ref, val)
+	}
+	return wrapTypes(reflect.ValueOf(val))
+}
+
+func (t *Template) invalidReference(n *VarNode, err error) (reflect.Value, error) {
+	ref := refString(n)
+	for _, h := range t.events.invalid {
+		if v, ok := h.InvalidReference(ref, err); ok {
+			return wrapTypes(reflect.ValueOf(v)), nil
+		}
+	}
+	return reflect.Value{}, err
+}
+
+func (t *Template) include(name, current, directive string) (string, error) {
+	var err error
+	for _, h := range t.events.include {
+		if name, err = h.Include(name, current, directive); err != nil {
+			return "", err
+		}
+	}
+	return name, nil
+}
+
+func (t *Template) methodException(v reflect.Value, meth string, err error) (reflect.Value, error) {
+	for _, h := range t.events.method {
+		var val interface{}
+		if val, err = h.MethodException(v.Interface(), meth, err); err == nil {
+			return wrapTypes(reflect.ValueOf(val)), nil
+		}
+	}
+	return reflect.Value{}, err
+}
+
+// refString returns the reference as it was written in the template
+func refString(n *VarNode) string {
+	var b strings.Builder
+	b.WriteByte('$')
+	b.WriteString(n.Name)
+	for _, item := range n.Items {
+		switch item.Kind {
+		case AccessIndex:
+			b.WriteByte('[')
+			writeArgs(&b, item.Args)
+			b.WriteByte(']')
+		case AccessMethod:
+			b.WriteByte('.')
+			b.WriteString(item.Name)
+			b.WriteByte('(')
+			writeArgs(&b, item.Args)
+			b.WriteByte(')')
+		default:
+			b.WriteByte('.')
+			b.WriteString(item.Name)
+		}
+	}
+	return b.String()
+}
+
+func writeArgs(b *strings.Builder, args []*OpNode) {
+	for i, arg := range args {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		switch v := arg.Val.(type) {
+		case *VarNode:
+			b.WriteString(refString(v))
+		case string:
+			fmt.Fprintf(b, "'%s'", v)
+		case int64, float64, bool:
+			fmt.Fprint(b, v)
+		default:
+			b.WriteString("...")
+		}
+	}
+}
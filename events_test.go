@@ -0,0 +1,195 @@
+package govtl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type insertFunc func(string, interface{}) interface{}
+
+func (f insertFunc) ReferenceInsert(ref string, v interface{}) interface{} { return f(ref, v) }
+
+type invalidFunc func(string, error) (interface{}, bool)
+
+func (f invalidFunc) InvalidReference(ref string, err error) (interface{}, bool) { return f(ref, err) }
+
+type includeFunc func(name, current, directive string) (string, error)
+
+func (f includeFunc) Include(name, current, directive string) (string, error) {
+	return f(name, current, directive)
+}
+
+type methodFunc func(interface{}, string, error) (interface{}, error)
+
+func (f methodFunc) MethodException(r interface{}, m string, err error) (interface{}, error) {
+	return f(r, m, err)
+}
+
+type failing struct{}
+
+func (failing) Fail(msg string) (string, error) { return "", errors.New(msg) }
+func (failing) Nil() *failing                   { return nil }
+func (failing) GetStatus() (string, error)      { return "", errors.New("recover") }
+func (failing) IsBroken() (bool, error)         { return false, errors.New("boom") }
+
+func TestEventReferenceInsertion(t *testing.T) {
+	var refs []string
+	record := insertFunc(func(ref string, v interface{}) interface{} {
+		refs = append(refs, ref)
+		return v
+	})
+	upper := insertFunc(func(ref string, v interface{}) interface{} {
+		if s, ok := v.(Str); ok {
+			return strings.ToUpper(string(s))
+		}
+		return v
+	})
+	tmpl, err := Parse(`$a $b.length() $m.k $list[0] $m.get('k').substring(1, 2) $!nothing`, "", "")
+	require.NoError(t, err)
+	tmpl.WithEventHandler(record).WithEventHandler(upper)
+	var b bytes.Buffer
+	data := _m{"a": "x", "b": "abc", "m": _m{"k": "value"}, "list": []int{1}, "nothing": nil}
+	require.NoError(t, tmpl.Execute(&b, data))
+	assert.Equal(t, "X 3 VALUE 1 A ", b.String())
+	assert.Equal(t, []string{"$a", "$b.length()", "$m.k", "$list[0]", "$m.get('k').substring(1, 2)", "$nothing"}, refs)
+}
+
+func TestEventReferenceInsertionEscape(t *testing.T) {
+	tmpl, err := Parse(`$a $b`, "", "")
+	require.NoError(t, err)
+	tmpl.WithEscape(EscapeHTML).WithEventHandler(insertFunc(func(ref string, v interface{}) interface{} {
+		if ref == "$a" {
+			return SafeString(fmt.Sprint(v))
+		}
+		return v
+	}))
+	var b bytes.Buffer
+	require.NoError(t, tmpl.Execute(&b, _m{"a": "<i>", "b": "<i>"}))
+	assert.Equal(t, "<i> &lt;i&gt;", b.String())
+}
+
+func TestEventInvalidReference(t *testing.T) {
+	handler := invalidFunc(func(ref string, err error) (interface{}, bool) {
+		if strings.HasPrefix(ref, "$keep") {
+			return nil, false
+		}
+		return "[" + ref + "]", true
+	})
+	tests := []struct {
+		name      string
+		tmpl      string
+		expect    string
+		expectErr string
+	}{
+		{"undefined", `$missing`, "[$missing]", ""},
+		{"undefined with property", `$missing.name`, "[$missing.name]", ""},
+		{"nil", `$f.nil()`, "[$f.nil()]", ""},
+		{"silent nil", `$!f.nil()`, "[$f.nil()]", ""},
+		{"alternate value first", `${missing|'alt'}`, "alt", ""},
+		{"set", `#set($x = $missing)$x`, "[$missing]", ""},
+		{"macro argument", `#macro(m $v)<$v>#end#m($missing)`, "<[$missing]>", ""},
+		{"not handled", `$keep`, "", "undefined var $keep"},
+		{"other errors", `$m.k.foo()`, "", "cannot call foo on int value"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			require.NoError(t, err)
+			tmpl.WithEventHandler(handler)
+			var b bytes.Buffer
+			err = tmpl.Execute(&b, _m{"f": failing{}, "m": _m{"k": 1}})
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, unposErr(err), test.expectErr)
+			}
+			assert.Equal(t, test.expect, b.String())
+		})
+	}
+}
+
+func TestEventInclude(t *testing.T) {
+	loader := MapLoader{
+		"page.vm":    `#include('header.vm')|#parse('body.vm')`,
+		"header.vm":  `header`,
+		"body.vm":    `body #parse('footer.vm')`,
+		"footer.vm":  `footer`,
+		"mobile.vm":  `mobile header`,
+		"private.vm": `secret`,
+	}
+	var calls []string
+	handler := includeFunc(func(name, current, directive string) (string, error) {
+		calls = append(calls, fmt.Sprintf("%s %s from %s", directive, name, current))
+		switch name {
+		case "header.vm":
+			return "mobile.vm", nil
+		case "private.vm":
+			return "", errors.New("not allowed")
+		}
+		return name, nil
+	})
+	tmpl, err := ParseName("page.vm", loader, "")
+	require.NoError(t, err)
+	tmpl.WithEventHandler(handler)
+	var b bytes.Buffer
+	require.NoError(t, tmpl.Execute(&b, nil))
+	assert.Equal(t, "mobile header|body footer", b.String())
+	assert.Equal(t, []string{
+		"include header.vm from page.vm",
+		"parse body.vm from page.vm",
+		"parse footer.vm from body.vm",
+	}, calls)
+
+	tmpl, err = Parse(`#include('private.vm')`, "", "")
+	require.NoError(t, err)
+	err = tmpl.WithEventHandler(handler).Execute(&b, nil)
+	assert.EqualError(t, unposErr(err), "not allowed")
+}
+
+func TestEventMethodException(t *testing.T) {
+	handler := methodFunc(func(r interface{}, m string, err error) (interface{}, error) {
+		if err.Error() == "recover" {
+			return fmt.Sprintf("%T.%s failed", r, m), nil
+		}
+		return nil, fmt.Errorf("translated: %w", err)
+	})
+	tests := []struct {
+		name      string
+		tmpl      string
+		expect    string
+		expectErr string
+	}{
+		{"recovered", `$f.fail('recover')`, "govtl.failing.fail failed", ""},
+		{"translated", `$f.fail('boom')`, "", "translated: boom"},
+		{"not a method error", `$f.fail()`, "", "incompatible number of arguments"},
+		{"recovered getter", `$f.status`, "govtl.failing.status failed", ""},
+		{"translated getter", `$f.broken`, "", "translated: boom"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			require.NoError(t, err)
+			tmpl.WithEventHandler(handler)
+			var b bytes.Buffer
+			err = tmpl.Execute(&b, _m{"f": failing{}})
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, unposErr(err), test.expectErr)
+			}
+			assert.Equal(t, test.expect, b.String())
+		})
+	}
+}
+
+func TestEventHandlerInvalid(t *testing.T) {
+	tmpl, err := Parse(``, "", "")
+	require.NoError(t, err)
+	assert.Panics(t, func() { tmpl.WithEventHandler(struct{}{}) })
+}
@@ -2,12 +2,11 @@ package govtl
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"math"
-	"path/filepath"
 	"reflect"
 	"sort"
 	"strconv"
@@ -26,21 +25,25 @@ type undefinedError struct {
 type nilError struct {
 	error
 }
-type posError struct {
-	error
-	Pos
-}
 
-func (p posError) Error() string {
-	return fmt.Sprintf("%v at line %d", p.error, p.Pos.line)
+func (t *Template) Execute(w io.Writer, val map[string]interface{}) error {
+	return t.ExecuteContext(context.Background(), w, val)
 }
 
-func (t *Template) Execute(w io.Writer, val map[string]interface{}) error {
+// ExecuteContext is like Execute, but stops with an error wrapping c.Err()
+// when c is canceled. Cancellation is checked on each loop iteration, macro
+// call and #parse
+func (t *Template) ExecuteContext(c context.Context, w io.Writer, val map[string]interface{}) error {
 	ctx := NewContext()
+	ctx.done = c
+	ctx.name = t.name
 	for k, v := range val {
 		vv := reflect.ValueOf(v)
 		ctx.Push(k, wrapTypes(vv))
 	}
+	if t.funcs != nil {
+		ctx.Push(FuncsVar, reflect.ValueOf(t.funcs))
+	}
 	_, err := t._execute(w, t.tree, ctx)
 	return err
 }
@@ -48,10 +51,13 @@ func (t *Template) Execute(w io.Writer, val map[string]interface{}) error {
 func wrapTypes(v reflect.Value) reflect.Value {
 	switch v.Kind() {
 	case reflect.Slice:
-		return reflect.ValueOf(&Slice{v.Interface()})
+		return reflect.ValueOf(&Slice{s: v.Interface()})
 	case reflect.Map:
-		return reflect.ValueOf(&Map{v.Interface()})
+		return reflect.ValueOf(&Map{m: v.Interface()})
 	case reflect.String:
+		if v.Type() == safeStringType {
+			return v
+		}
 		return v.Convert(reflect.TypeOf(Str("")))
 	case reflect.Interface:
 		return wrapTypes(v.Elem())
@@ -66,23 +72,27 @@ func wrapTypes(v reflect.Value) reflect.Value {
 	}
 }
 
-func (t *Template) error(err error) error {
+func (ctx Ctx) error(err error) error {
 	if err == nil {
 		return nil
 	}
-	if poser, ok := err.(posError); ok {
-		return poser
+	if e, ok := err.(*Error); ok {
+		return e
 	}
-	return posError{err, t.pos}
+	frames := make([]Frame, len(ctx.frames))
+	for i, f := range ctx.frames {
+		frames[len(frames)-1-i] = f
+	}
+	return &Error{ctx.name, ctx.pos.line, ctx.pos.col, frames, err}
 }
 
 func (t *Template) _execute(w io.Writer, list []Node, ctx Ctx) (shouldStop bool, err error) {
 	if t.maxCallDepth >= 0 && ctx.callDepth > t.maxCallDepth {
-		return true, t.error(errors.New("call depth exceeded"))
+		return true, ctx.error(errors.New("call depth exceeded"))
 	}
 	for _, expr := range list {
 		if poser, ok := expr.(PositionedNode); ok {
-			t.pos = poser.Position()
+			ctx.pos = poser.Position()
 		}
 		switch n := expr.(type) {
 		case TextNode:
@@ -95,13 +105,13 @@ func (t *Template) _execute(w io.Writer, list []Node, ctx Ctx) (shouldStop bool,
 			if n.Cond == nil {
 				stop, err = t._execute(w, n.Items, ctx)
 				if stop {
-					return true, t.error(err)
+					return true, ctx.error(err)
 				}
 				break
 			}
 			cond, err := t.eval(n.Cond, ctx, true)
 			if err != nil && !errors.As(err, &undefinedError{}) && !errors.As(err, &nilError{}) {
-				return true, t.error(err)
+				return true, ctx.error(err)
 			}
 			if isTrue(cond) {
 				stop, err = t._execute(w, n.Items, ctx)
@@ -109,20 +119,20 @@ func (t *Template) _execute(w io.Writer, list []Node, ctx Ctx) (shouldStop bool,
 				stop, err = t._execute(w, []Node{n.Else}, ctx)
 			}
 			if stop {
-				return true, t.error(err)
+				return true, ctx.error(err)
 			}
 		case *SetNode:
 			val, err := t.eval(n.Expr, ctx, false)
 			if errors.As(err, &nilError{}) {
 			} else if err != nil {
-				return false, t.error(err)
+				return false, ctx.error(err)
 			}
 			if len(n.Var.Items) == 0 {
 				depth := ctx.Push(n.Var.Name, val)
 				defer ctx.Pop(depth, n.Var.Name)
 			} else if val.IsValid() {
 				if err := t.setVar(n.Var, val, ctx); err != nil {
-					return false, t.error(err)
+					return false, ctx.error(err)
 				}
 			}
 		case *OpNode:
@@ -130,50 +140,77 @@ func (t *Template) _execute(w io.Writer, list []Node, ctx Ctx) (shouldStop bool,
 		case *VarNode:
 			v, err := t.evalVar(n, ctx)
 			if err != nil && !(n.Silent && errors.As(err, &nilError{})) {
-				return false, t.error(err)
+				return false, ctx.error(err)
 			}
-			if v.IsValid() {
+			if v.IsValid() && v.Type() == blockType {
+				if err := v.Interface().(*block).render(w, ctx); err != nil {
+					return true, ctx.error(err)
+				}
+			} else if v = t.referenceInsert(n, v); v.IsValid() {
 				b := bufPool.Get().(*bytes.Buffer)
 				b.Reset()
 				err := t.vtlPrint(b, v, nil)
 				if err != nil {
-					return true, t.error(err)
+					return true, ctx.error(err)
+				}
+				if t.escape != EscapeNone && !ctx.raw && v.Type() != safeStringType {
+					io.WriteString(w, t.escape.Escape(b.String()))
+				} else {
+					b.WriteTo(w)
 				}
-				b.WriteTo(w)
 				bufPool.Put(b)
 			}
+		case *DefineNode:
+			depth := ctx.Push(n.Var.Name, reflect.ValueOf(&block{t, n.Items, -1, ctx.name}))
+			defer ctx.Pop(depth, n.Var.Name)
 		case *MacroNode:
-			if _, ok := t.macros[n.Name]; !ok {
-				t.macros[n.Name] = n
+			if _, ok := t.macro(n.Name, ctx); !ok {
+				ctx.macros[n.Name] = n
 			}
 		case *MacroCall:
-			m, ok := t.macros[n.Name]
+			m, ok := t.macro(n.Name, ctx)
 			if !ok {
-				return false, t.error(fmt.Errorf("undefined macro '%s' call", n.Name))
+				return false, ctx.error(fmt.Errorf("undefined macro '%s' call", n.Name))
+			}
+			if err := ctx.canceled(); err != nil {
+				return true, err
 			}
 			if len(n.Vals) < len(m.Assign) {
-				return false, t.error(fmt.Errorf("variable $%s has not been set", m.Assign[len(n.Vals)].Name))
+				return false, ctx.error(fmt.Errorf("variable $%s has not been set", m.Assign[len(n.Vals)].Name))
 			}
 			for i := range m.Assign {
 				v, err := t.eval(n.Vals[i], ctx, false)
 				if err != nil {
-					return true, t.error(err)
+					return true, ctx.error(err)
 				}
 				depth := ctx.Push(m.Assign[i].Name, v)
 				defer ctx.Pop(depth, m.Assign[i].Name)
 			}
-			ctx.callDepth++
-			stop, err := t._execute(w, m.Items, ctx)
-			ctx.callDepth--
+			// body of the block macro call is rendered lazily in the context of
+			// the macro
+			bdepth := -1
+			if n.Body != nil {
+				body := &block{t, n.Body, len(ctx.s["bodyContent"]), ctx.name}
+				bdepth = ctx.Push("bodyContent", reflect.ValueOf(body))
+			}
+			stop, err := t._execute(w, m.Items, ctx.call("#"+n.Name, m.file))
+			ctx.Pop(bdepth, "bodyContent")
 			if err != nil {
-				return true, t.error(err)
+				return true, ctx.error(err)
 			} else if stop {
 				return false, nil
 			}
+		case *DirectiveNode:
+			stop, err := t.renderDirective(w, n, ctx)
+			if err != nil {
+				return true, ctx.error(err)
+			} else if stop {
+				return true, nil
+			}
 		case *ForeachNode:
 			iter, err := t.eval(n.Iter, ctx, false)
 			if err != nil {
-				return true, t.error(err)
+				return true, ctx.error(err)
 			}
 			if !iter.IsValid() {
 				break
@@ -189,26 +226,43 @@ func (t *Template) _execute(w io.Writer, list []Node, ctx Ctx) (shouldStop bool,
 			case collIteratorType, mapIteratorType:
 				f.it = iter.Interface().(Iterator)
 			default:
-				return true, t.error(fmt.Errorf("cannot iterate over %s", getKind(iter)))
+				return true, ctx.error(fmt.Errorf("cannot iterate over %s", getKind(iter)))
+			}
+			switch t.iteration {
+			case IterateFailFast:
+				if it, ok := f.it.(failFaster); ok {
+					it.failFast()
+				}
+			case IterateSnapshot:
+				if f.it, err = snapshot(f.it, t.maxIterations); err != nil {
+					return true, ctx.error(err)
+				}
 			}
 			empty := true
 			for f.it.HasNext() {
+				ctx.pos = n.Pos
+				if err := ctx.canceled(); err != nil {
+					return true, err
+				}
 				f.i++
 				if t.maxIterations >= 0 && f.Count() > t.maxIterations {
-					return true, t.error(errors.New("number of iterations exceeded"))
+					return true, ctx.error(errors.New("number of iterations exceeded"))
 				}
 				empty = false
-				v, _ := f.it.Next()
-				ctx.Set(vdepth, n.Var.Name, reflect.ValueOf(v))
-				_, err := t._execute(w, n.Items, ctx)
+				v, err := f.it.Next()
+				if err != nil {
+					return true, ctx.error(err)
+				}
+				ctx.Set(vdepth, n.Var.Name, wrapTypes(reflect.ValueOf(v)))
+				_, err = t._execute(w, n.Items, ctx)
 				if err != nil {
-					return true, t.error(err)
+					return true, ctx.error(err)
 				}
 			}
 			if empty && n.Else != nil {
 				_, err := t._execute(w, n.Else, ctx)
 				if err != nil {
-					return true, t.error(err)
+					return true, ctx.error(err)
 				}
 			}
 			ctx.Pop(vdepth, n.Var.Name)
@@ -221,41 +275,91 @@ func (t *Template) _execute(w io.Writer, list []Node, ctx Ctx) (shouldStop bool,
 			for _, v := range n.Names {
 				name, err := t.eval(v, ctx, false)
 				if err != nil {
-					return true, t.error(err)
+					return true, ctx.error(err)
 				}
 				var file string
 				switch {
 				case name.IsValid() && name.Kind() == reflect.String:
 					file = name.String()
-				case name.IsValid() && name.Type().Implements(reflect.TypeOf((*fmt.Stringer)(nil)).Elem()):
+				case name.IsValid() && name.Type().Implements(stringerType):
+					if err := t.policy.check(name, "String"); err != nil {
+						return true, ctx.error(err)
+					}
 					file = fmt.Sprintf("%v", name.Interface())
 				default:
-					return false, t.error(errors.New("invalid include argument"))
+					return false, ctx.error(errors.New("invalid include argument"))
 				}
 
-				data, err := ioutil.ReadFile(filepath.Join(t.root, file))
+				if file, err = t.include(file, ctx.name, "include"); err != nil {
+					return true, ctx.error(err)
+				}
+				data, err := t.loader.Load(file)
 				if err != nil {
-					return true, t.error(err)
+					return true, ctx.error(err)
 				}
 				w.Write(data)
 			}
 		case *ParseNode:
+			if err := ctx.canceled(); err != nil {
+				return true, err
+			}
 			name, err := t.eval(n.Name, ctx, false)
 			if err != nil {
-				return true, t.error(err)
+				return true, ctx.error(err)
+			}
+			// parsed template shares library macros and settings, but macros
+			// defined inside are local to it
+			file, err := t.include(name.String(), ctx.name, "parse")
+			if err != nil {
+				return true, ctx.error(err)
 			}
-			tmpl, err := ParseFile(filepath.Join(t.root, name.String()), t.root, t.lib)
+			tree, err := t.parseTree(file)
 			if err != nil {
-				return true, t.error(err)
+				return true, ctx.error(err)
 			}
-			ctx.callDepth++
-			stop, err := tmpl._execute(w, tmpl.tree, ctx)
-			ctx.callDepth--
+			pctx := ctx.call("#parse", file)
+			pctx.state = newState()
+			stop, err := t._execute(w, tree, pctx)
 			if stop {
-				return true, t.error(err)
+				return true, ctx.error(err)
 			}
 		case *EvalNode:
-			return true, t.error(errors.New("eval is not supported"))
+			v, err := t.eval(n.Content, ctx, false)
+			if err != nil {
+				return true, ctx.error(err)
+			}
+			var vtl string
+			switch {
+			case !v.IsValid():
+			case v.Kind() == reflect.String:
+				vtl = v.String()
+			default:
+				b := bufPool.Get().(*bytes.Buffer)
+				b.Reset()
+				err := t.vtlPrint(b, v, nil)
+				vtl = b.String()
+				bufPool.Put(b)
+				if err != nil {
+					return true, ctx.error(err)
+				}
+			}
+			tree, err := parse(ctx.name, vtl, t.directives, t.macros, ctx.macros)
+			if err != nil {
+				return true, ctx.error(fmt.Errorf("evaluate: %w", err))
+			}
+			// evaluated content shares macros with the template, but has its own
+			// positions, the position of #evaluate is kept in the call chain
+			ectx := ctx.call("#evaluate", ctx.name)
+			ectx.state = &state{macros: ctx.macros}
+			if t.maxCallDepth >= 0 && ectx.callDepth > t.maxCallDepth {
+				return true, ctx.error(errors.New("call depth exceeded"))
+			}
+			stop, err := t._execute(w, tree, ectx)
+			if err != nil {
+				return true, ctx.error(err)
+			} else if stop {
+				return true, nil
+			}
 		default:
 			panic(fmt.Sprintf("unexpected %T, %[1]v", n))
 		}
@@ -263,6 +367,16 @@ func (t *Template) _execute(w io.Writer, list []Node, ctx Ctx) (shouldStop bool,
 	return false, nil
 }
 
+// macro looks up macro by name, first in the library and then in the macros
+// defined during current execution
+func (t *Template) macro(name string, ctx Ctx) (*MacroNode, bool) {
+	if m, ok := t.macros[name]; ok {
+		return m, true
+	}
+	m, ok := ctx.macros[name]
+	return m, ok
+}
+
 func (t *Template) evalStep(v reflect.Value, m *AccessNode, wrapT bool, ctx Ctx) (reflect.Value, error) {
 	var args []reflect.Value
 	var err error
@@ -291,10 +405,26 @@ func (t *Template) evalStep(v reflect.Value, m *AccessNode, wrapT bool, ctx Ctx)
 }
 
 func (t *Template) evalVar(n *VarNode, ctx Ctx) (reflect.Value, error) {
+	v, err := t.evalRef(n, ctx)
+	if n.Alt != nil && (err == nil && !isTrue(v) || errors.As(err, &undefinedError{}) || errors.As(err, &nilError{})) {
+		v, err = t.eval(n.Alt, ctx, false)
+	}
+	if len(t.events.invalid) > 0 && (errors.As(err, &undefinedError{}) || errors.As(err, &nilError{})) {
+		return t.invalidReference(n, err)
+	}
+	return v, err
+}
+
+func (t *Template) evalRef(n *VarNode, ctx Ctx) (reflect.Value, error) {
 	v, err := ctx.Get(n.Name)
 	if err != nil {
 		return v, err
 	}
+	if len(n.Items) > 0 && v.IsValid() && v.Type() == blockType {
+		if v, err = v.Interface().(*block).value(ctx); err != nil {
+			return v, err
+		}
+	}
 	for _, m := range n.Items {
 		if v, err = t.evalStep(v, m, true, ctx); err != nil {
 			return v, err
@@ -346,6 +476,10 @@ func (t *Template) setVar(n *VarNode, val reflect.Value, ctx Ctx) error {
 		}
 		prev := v
 		v, err = t.property(v, reflect.ValueOf(last.Name))
+		var nae *NotAllowedError
+		if errors.As(err, &nae) {
+			return err
+		}
 		if !v.IsValid() {
 			return fmt.Errorf("cannot set %s on %s value", last.Name, getKind(prev))
 		}
@@ -360,6 +494,9 @@ func (t *Template) setVar(n *VarNode, val reflect.Value, ctx Ctx) error {
 		if !f.IsValid() {
 			return fmt.Errorf("cannot set %s on %s value", last.Name, getKind(prev))
 		}
+		if err := t.policy.checkFieldSet(prev, strings.Title(last.Name)); err != nil {
+			return err
+		}
 		s := val.Convert(f.Type())
 		f.Set(s)
 		return nil
@@ -420,6 +557,9 @@ func (t *Template) eval(e *OpNode, ctx Ctx, undefOk bool) (reflect.Value, error)
 		if err != nil && !(undefOk && errors.As(err, &undefinedError{})) {
 			return r, err
 		}
+		if e.Op == "map" && t.orderedMaps {
+			return reflect.ValueOf(mapLiteral(l, newKeyOrder())), nil
+		}
 		ret, err := reflectCall(f, reflect.ValueOf(l), reflect.ValueOf(r))
 		if err != nil {
 			return reflect.Value{}, err
@@ -434,13 +574,19 @@ func (t *Template) eval(e *OpNode, ctx Ctx, undefOk bool) (reflect.Value, error)
 		b := bufPool.Get().(*bytes.Buffer)
 		b.Reset()
 		defer bufPool.Put(b)
-		_, err := t._execute(b, val.Items, ctx)
+		ictx := ctx
+		ictx.raw = true
+		_, err := t._execute(b, val.Items, ictx)
 		if err != nil {
 			return reflect.Value{}, err
 		}
 		return wrapTypes(reflect.ValueOf(b.String())), nil
 	case *VarNode:
-		return t.evalVar(val, ctx)
+		v, err := t.evalVar(val, ctx)
+		if err == nil && v.IsValid() && v.Type() == blockType {
+			return v.Interface().(*block).value(ctx)
+		}
+		return v, err
 	case nil:
 	case int64, float64, bool:
 		return reflect.ValueOf(val), nil
@@ -459,7 +605,7 @@ func (t *Template) eval(e *OpNode, ctx Ctx, undefOk bool) (reflect.Value, error)
 				vv[i] = e.Interface()
 			}
 		}
-		return reflect.ValueOf(&Slice{vv}), nil
+		return reflect.ValueOf(&Slice{s: vv}), nil
 	default:
 		return wrapTypes(reflect.ValueOf(val)), nil
 	}
@@ -620,21 +766,7 @@ var funcs = map[string]interface{}{
 	"not": func(v1 reflect.Value, v2 interface{}) bool { return !isTrue(v1) },
 
 	"map": func(v1, v2 reflect.Value) (reflect.Value, error) {
-		if !v1.IsValid() {
-			return reflect.ValueOf(&Map{map[string]interface{}{}}), nil
-		}
-		val := reflect.ValueOf(v1.Interface().(*Slice).s)
-		m := make(map[string]interface{}, val.Len()/2)
-		b := bufPool.Get().(*bytes.Buffer)
-		defer bufPool.Put(b)
-		b.Reset()
-		for i := 0; i < val.Len(); i += 2 {
-			k, v := val.Index(i), val.Index(i+1)
-			kk := fmt.Sprint(k.Interface())
-			m[kk] = v.Interface()
-			b.Reset()
-		}
-		return reflect.ValueOf(&Map{m}), nil
+		return reflect.ValueOf(mapLiteral(v1, nil)), nil
 	},
 	"list": func(v1, v2 reflect.Value) reflect.Value { return v1 },
 
@@ -670,6 +802,24 @@ var funcs = map[string]interface{}{
 	},
 }
 
+// mapLiteral builds map from the list of keys and values, order is nil
+// for maps iterated in sorted key order. Keys are converted to strings,
+// keys of other types come only from Go maps
+func mapLiteral(list reflect.Value, order *keyOrder) *Map {
+	if !list.IsValid() {
+		return &Map{m: map[string]interface{}{}, order: order}
+	}
+	val := reflect.ValueOf(list.Interface().(*Slice).s)
+	m := make(map[string]interface{}, val.Len()/2)
+	for i := 0; i < val.Len(); i += 2 {
+		k, v := val.Index(i), val.Index(i+1)
+		kk := fmt.Sprint(k.Interface())
+		m[kk] = v.Interface()
+		order.add(reflect.ValueOf(kk))
+	}
+	return &Map{m: m, order: order}
+}
+
 func overflowsInt64(v reflect.Value) bool {
 	if v.Kind() == reflect.Float64 {
 		return v.Float() > float64(math.MaxInt64) || v.Float() < float64(math.MinInt64)
@@ -677,6 +827,42 @@ func overflowsInt64(v reflect.Value) bool {
 	return false
 }
 
+// IterationPolicy defines what #foreach does when the iterated collection
+// is modified in the loop
+type IterationPolicy int
+
+const (
+	// IterateLive sees the changes, elements could be skipped or repeated
+	IterateLive IterationPolicy = iota
+	// IterateFailFast stops with ErrConcurrentModification when elements
+	// are added or removed not through the iterator
+	IterateFailFast
+	// IterateSnapshot iterates over elements the collection had when the
+	// loop started
+	IterateSnapshot
+)
+
+// WithIterationPolicy sets policy for collections modified during #foreach,
+// default is IterateLive
+func (t *Template) WithIterationPolicy(p IterationPolicy) *Template {
+	t.iteration = p
+	return t
+}
+
+// snapshot reads remaining elements of it, but no more than one above the
+// limit of iterations, if it is set
+func snapshot(it Iterator, limit int) (Iterator, error) {
+	var items []interface{}
+	for it.HasNext() && (limit < 0 || len(items) <= limit) {
+		v, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+	return &CollectionIterator{s: &Slice{s: items}}, nil
+}
+
 type foreach struct {
 	it Iterator
 	i  int
@@ -704,8 +890,46 @@ var (
 	valViewType      = reflect.TypeOf((*ValView)(nil))
 	collIteratorType = reflect.TypeOf((*CollectionIterator)(nil))
 	mapIteratorType  = reflect.TypeOf((*MapIterator)(nil))
+	blockType        = reflect.TypeOf((*block)(nil))
+	funcSetType      = reflect.TypeOf(funcSet(nil))
+	stringerType     = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
 )
 
+// block is a value of #define'd reference, it is rendered in the context
+// of every place it is referenced
+type block struct {
+	t     *Template
+	items []Node
+	// for the body of block macro call - depth of $bodyContent at the moment
+	// of the call, so the body sees $bodyContent of the caller, not its own
+	bodyDepth int
+	// name of the template where block is defined
+	name string
+}
+
+func (b *block) kind() string { return "block" }
+
+func (b *block) render(w io.Writer, ctx Ctx) error {
+	if body := ctx.s["bodyContent"]; b.bodyDepth >= 0 && b.bodyDepth <= len(body) {
+		ctx.s["bodyContent"] = append([]reflect.Value(nil), body[:b.bodyDepth]...)
+		defer func() { ctx.s["bodyContent"] = body }()
+	}
+	ctx.callDepth++
+	ctx.name = b.name
+	_, err := b.t._execute(w, b.items, ctx)
+	return err
+}
+
+// value renders the block to a string, which is used when the reference
+// is a value of expression rather than printed
+func (b *block) value(ctx Ctx) (reflect.Value, error) {
+	var buf bytes.Buffer
+	if err := b.render(&buf, ctx); err != nil {
+		return reflect.Value{}, err
+	}
+	return wrapTypes(reflect.ValueOf(buf.String())), nil
+}
+
 type Iterable interface {
 	Iterator() Iterator
 }
@@ -812,7 +1036,10 @@ func (t *Template) vtlPrint(b *bytes.Buffer, v reflect.Value, path []uintptr) er
 			}
 			return t.vtlPrint(b, reflect.ValueOf(s.Iterator()), path)
 		default:
-			if v.Type().Implements(reflect.TypeOf((*fmt.Stringer)(nil)).Elem()) {
+			if v.Type().Implements(stringerType) {
+				if err := t.policy.check(v, "String"); err != nil {
+					return err
+				}
 				fmt.Fprintf(b, "%v", v.Interface())
 				return nil
 			}
@@ -835,6 +1062,9 @@ func (t *Template) vtlPrint(b *bytes.Buffer, v reflect.Value, path []uintptr) er
 			if fT.PkgPath != "" {
 				continue
 			}
+			if err := t.policy.check(v, fT.Name); err != nil {
+				return err
+			}
 			if i > 0 {
 				b.WriteString(", ")
 			}
@@ -847,6 +1077,11 @@ func (t *Template) vtlPrint(b *bytes.Buffer, v reflect.Value, path []uintptr) er
 		}
 		b.WriteByte('}')
 	default:
+		if v.Type().Implements(stringerType) {
+			if err := t.policy.check(v, "String"); err != nil {
+				return err
+			}
+		}
 		fmt.Fprintf(b, "%v", v.Interface())
 	}
 	return nil
@@ -865,6 +1100,9 @@ func (t *Template) property(v1, v2 reflect.Value) (reflect.Value, error) {
 			if ok && field.PkgPath == "" {
 				ret = vv1.FieldByName(f)
 				if ret.IsValid() {
+					if err := t.policy.check(v1, f); err != nil {
+						return reflect.Value{}, err
+					}
 					return ret, nil
 				}
 			}
@@ -872,7 +1110,14 @@ func (t *Template) property(v1, v2 reflect.Value) (reflect.Value, error) {
 		for _, mm := range []string{"Get", "Is"} {
 			m := t.findMethod(v1, mm+f)
 			if m.IsValid() && m.Type().NumIn() == 0 {
-				return reflectCall(m)
+				if err := t.policy.check(v1, mm+f); err != nil {
+					return reflect.Value{}, err
+				}
+				ret, err = reflectCall(m)
+				if err != nil && len(t.events.method) > 0 {
+					return t.methodException(v1, v2.String(), err)
+				}
+				return ret, err
 			}
 		}
 	}
@@ -921,25 +1166,40 @@ func (t *Template) call(v reflect.Value, meth string, args ...reflect.Value) (re
 	if !v.IsValid() {
 		return reflect.Value{}, fmt.Errorf("cannot call %s on nil value", meth)
 	}
+	if v.Type() == funcSetType {
+		return t.callFunc(v.Interface().(funcSet), meth, args...)
+	}
 	tt := []byte(meth)
 	trimm := ucFirst(string(bytes.TrimPrefix(tt, []byte("get"))))
-	m := t.findMethod(v, ucFirst(string(tt)))
+	name := ucFirst(string(tt))
+	m := t.findMethod(v, name)
 	for _, mm := range []string{"Get", "Is"} {
 		if m.IsValid() {
 			break
 		}
-		m = t.findMethod(v, mm+trimm)
+		name = mm + trimm
+		m = t.findMethod(v, name)
 	}
 	vv := indirect(v)
 	switch {
 	case m.IsValid():
+		if err := t.policy.check(v, name); err != nil {
+			return reflect.Value{}, err
+		}
 		if err := compatible(m, args...); err != nil {
 			return reflect.Value{}, err
 		}
-		return reflectCall(m, args...)
+		ret, err := reflectCall(m, args...)
+		if err != nil && len(t.events.method) > 0 {
+			return t.methodException(v, meth, err)
+		}
+		return ret, err
 	case vv.Kind() == reflect.Struct:
 		f := vv.FieldByName(trimm)
 		if f.IsValid() {
+			if err := t.policy.check(v, trimm); err != nil {
+				return reflect.Value{}, err
+			}
 			return wrapTypes(f), nil
 		}
 	case vv.Type() == reflect.TypeOf(""):
@@ -1043,31 +1303,40 @@ func comparable(k1, k2 reflect.Value) bool {
 }
 
 func compatible(f reflect.Value, args ...reflect.Value) error {
-	if len(args) < f.Type().NumIn() || (!f.Type().IsVariadic() && len(args) > f.Type().NumIn()) {
+	ft := f.Type()
+	variadic := ft.IsVariadic()
+	if variadic && len(args) < ft.NumIn()-1 || !variadic && len(args) != ft.NumIn() {
 		return errors.New("incompatible number of arguments")
 	}
 	for i, val := range args {
 		var argType reflect.Type
-		if f.Type().IsVariadic() {
-			argType = f.Type().In(f.Type().NumIn() - 1).Elem()
+		if variadic && i >= ft.NumIn()-1 {
+			argType = ft.In(ft.NumIn() - 1).Elem()
 		} else {
-			argType = f.Type().In(i)
+			argType = ft.In(i)
 		}
-		if !val.IsValid() {
-			return fmt.Errorf("arg %d not valid", i)
-		}
-		valType := val.Type()
-		if !valType.AssignableTo(argType) {
-			if valType.ConvertibleTo(argType) {
-				args[i] = val.Convert(argType)
-			} else {
-				return fmt.Errorf("arg %d: %s %s -> %s", i, "not assignable", valType, f.Type().In(i))
-			}
+		v, err := convertValue(val, argType)
+		if err != nil {
+			return fmt.Errorf("arg %d: %w", i, err)
 		}
+		args[i] = v
 	}
 	return nil
 }
 
+// native returns Go value wrapped by *Slice or *Map, invalid value for
+// other types
+func native(v reflect.Value) reflect.Value {
+	switch vv := v.Interface().(type) {
+	case *Slice:
+		vv.checkNil()
+		return reflect.ValueOf(vv.s)
+	case *Map:
+		return reflect.ValueOf(vv.m)
+	}
+	return reflect.Value{}
+}
+
 func ucFirst(s string) string {
 	if len(s) > 0 && s[0] > 'Z' {
 		b := []byte(s)
@@ -2,10 +2,13 @@ package govtl
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"html/template"
 	"io/ioutil"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -667,9 +670,9 @@ func TestExecuteForeach_Argument(t *testing.T) {
 		{"non-empty map var",
 			`#foreach($x in $some)x: $x#end`, _m{"some": map[string]string{"": ""}}, "x: ", ""},
 		{"empty iterator",
-			`#foreach($x in $some)x: $x#end`, _m{"some": (&Map{map[string]int{}}).EntrySet()}, "", ""},
+			`#foreach($x in $some)x: $x#end`, _m{"some": (&Map{m: map[string]int{}}).EntrySet()}, "", ""},
 		{"non-empty iterator",
-			`#foreach($x in $some)x: $x#end`, _m{"some": (&Map{map[string]string{"": ""}}).EntrySet()}, "x: =", ""},
+			`#foreach($x in $some)x: $x#end`, _m{"some": (&Map{m: map[string]string{"": ""}}).EntrySet()}, "x: =", ""},
 		// expressions
 		{"non-empty iterator from array",
 			`#foreach($x in $some.iterator())x: $x#end`, _m{"some": []string{""}}, "x: ", ""},
@@ -726,6 +729,12 @@ hasNext: false
 `, ""},
 		{"if, set and other foreach",
 			`#foreach($x in [1..2])x: $x#if($foreach.hasNext), #end#set($l = [2, 3])#if($x > 1)!#foreach($y in $l)$y#end#end#end`, nil, "x: 1, x: 2!23", ""},
+		{"elements of go values are wrapped",
+			`#foreach($x in $l)$x.size() $x.get('k') $x.k #end#foreach($x in $n)$x.size() #end#foreach($x in $s)$x.length() #end`,
+			_m{"l": []interface{}{map[string]interface{}{"k": "a"}}, "n": [][]int{{1, 2}}, "s": []string{"ab"}}, "1 a a 2 2 ", ""},
+		{"set property of the map element",
+			`#foreach($m in $l)$m.k#set($m.k = "x")$m.k #end$l[0].k`,
+			_m{"l": []map[string]string{{"k": "a"}, {"k": "b"}}}, "ax bx x", ""},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -744,6 +753,593 @@ hasNext: false
 	}
 }
 
+func TestExecuteUnparsed(t *testing.T) {
+	tests := []struct {
+		name      string
+		tmpl      string
+		context   _m
+		expect    string
+		expectErr string
+	}{
+		{"references and directives",
+			`#[[$x #set($x = 1) #if($x)${x}#end]]#`, _m{"x": "X"}, "$x #set($x = 1) #if($x)${x}#end", ""},
+		{"mixed with text",
+			`$x #[[$x]]# $x`, _m{"x": "X"}, "X $x X", ""},
+		{"escapes are kept",
+			`#[[\$x \#end]]#`, nil, `\$x \#end`, ""},
+		{"javascript",
+			"#[[\n$(function() { $('#id').hide(); });\n]]#", nil, "\n$(function() { $('#id').hide(); });\n", ""},
+		{"inside directive",
+			"#if(true)\n  #[[$x]]#\n#end", nil, "  $x\n", ""},
+		{"at the line with directives",
+			"#set($a = 1)\n#[[\n  #$x\n]]#\n#set($b = 1)\n", nil, "\n  #$x\n\n", ""},
+		{"unterminated",
+			`#[[$x`, nil, "", "unexpected $end, expected ]]#: line 1, column 1 (|#[[$x)"},
+		{"line numbers after block",
+			"#[[\n\n]]#\n$x", nil, "\n\n\n", "undefined var $x at line 4, column 1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			if err != nil {
+				assert.EqualError(t, err, test.expectErr)
+				return
+			}
+			var b bytes.Buffer
+			err = tmpl.Execute(&b, test.context)
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectErr)
+			}
+			assert.Equal(t, test.expect, b.String())
+		})
+	}
+}
+
+func TestExecuteDefine(t *testing.T) {
+	tests := []struct {
+		name      string
+		tmpl      string
+		context   _m
+		expect    string
+		expectErr string
+	}{
+		{"simple block",
+			`#define($block)Hello $name#end$block, $block`, _m{"name": "world"}, "Hello world, Hello world", ""},
+		{"evaluated lazily",
+			`#define($block)$name#end#set($name = "a")$block#set($name = "b")$block`, nil, "ab", ""},
+		{"current context",
+			`#define($block)$x#end#foreach($x in [1..3])$block#end`, nil, "123", ""},
+		{"formal and silent references",
+			`#define($block)x#end${block}$!block$!{block}`, nil, "xxx", ""},
+		{"inside string interpolation",
+			`#define($block)x#end#set($s = "[$block]")$s`, nil, "[x]", ""},
+		{"passed to macro",
+			`#define($block)x#end#macro(twice $b)$b$b#end#twice($block)`, nil, "xx", ""},
+		{"directives inside",
+			"#define($block)\n#if($x)yes#{else}no#end\n#end\n#set($x = true)$block", nil, "yes", ""},
+		{"concatenation",
+			`#define($h)H#end#set($s = $h + 'x')$s`, nil, "Hx", ""},
+		{"comparison",
+			`#define($h)H#end#if($h == 'H')eq#end#if($h != 'x')ne#end`, nil, "eqne", ""},
+		{"inside collections",
+			`#define($h)H#end#set($l = [$h])#set($m = {'k': $h})$l $m $l[0].length()`, nil, "[H] {k=H} 1", ""},
+		{"method of the block",
+			`#define($h)abc#end$h.length() $h.toUpperCase()`, nil, "3 ABC", ""},
+		{"method argument",
+			`#define($h)b#end$s.concat($h)`, _m{"s": "a"}, "ab", ""},
+		{"set is rendered in the current context",
+			`#define($h)$x#end#set($x = 1)#set($v = $h)#set($x = 2)$v$h`, nil, "12", ""},
+		{"redefine",
+			`#define($block)a#end#define($block)b#end$block`, nil, "b", ""},
+		{"undefined variable inside",
+			`#define($block)$nope#end$block`, nil, "", "undefined var $nope"},
+		{"recursion",
+			`#define($block)$block#end$block`, nil, "", "call depth exceeded"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			var b bytes.Buffer
+			if assert.NoError(t, err) {
+				err := tmpl.Execute(&b, test.context)
+				if test.expectErr == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, unposErr(err), test.expectErr)
+				}
+				assert.Equal(t, test.expect, b.String())
+			}
+		})
+	}
+}
+
+func TestExecuteEvaluate(t *testing.T) {
+	tests := []struct {
+		name      string
+		tmpl      string
+		context   _m
+		expect    string
+		expectErr string
+	}{
+		{"string literal",
+			`#evaluate('$x + #if(true)1#end')`, _m{"x": "X"}, "X + 1", ""},
+		{"reference",
+			`#evaluate($snippet)`, _m{"snippet": "Hello $name", "name": "world"}, "Hello world", ""},
+		{"interpolated string",
+			`#set($var = 'name')#evaluate("Hello \$$var")`, _m{"name": "world"}, "Hello world", ""},
+		{"property",
+			`#evaluate($page.body)`, _m{"page": map[string]string{"body": "#foreach($i in [1..3])$i#end"}}, "123", ""},
+		{"set is scoped",
+			`#set($x = 0)#evaluate('#set($x = 1)$x')$x`, nil, "10", ""},
+		{"uses macros",
+			`#macro(m $a)[$a]#end#evaluate('#m(1)')`, nil, "[1]", ""},
+		{"defines macros",
+			`#evaluate('#macro(m $a)[$a]#end')#evaluate('#m(2)')`, nil, "[2]", ""},
+		{"nested",
+			`#evaluate("#evaluate('\$x')")`, _m{"x": 1}, "1", ""},
+		{"non-string",
+			`#evaluate(42)`, nil, "42", ""},
+		{"stop",
+			`#evaluate('a#stop b')c`, nil, "a", ""},
+		{"undefined reference",
+			`#evaluate($snippet)`, nil, "", "undefined var $snippet"},
+		{"error inside",
+			"\n#evaluate('\n\n$y')", nil, "\n\n\n", "undefined var $y"},
+		{"recursion",
+			`#evaluate($s)`, _m{"s": "#evaluate($s)"}, "", "call depth exceeded"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			var b bytes.Buffer
+			if assert.NoError(t, err) {
+				err := tmpl.Execute(&b, test.context)
+				if test.expectErr == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, unposErr(err), test.expectErr)
+				}
+				assert.Equal(t, test.expect, b.String())
+			}
+		})
+	}
+
+	t.Run("inner and outer positions", func(t *testing.T) {
+		tmpl := Must(Parse("\n#evaluate('\n\n$y')", "", ""))
+		err := tmpl.Execute(ioutil.Discard, nil)
+		assert.EqualError(t, err, "undefined var $y at line 3, column 1")
+		var e *Error
+		if assert.True(t, errors.As(err, &e)) {
+			assert.Equal(t, []Frame{{"#evaluate", "", 2, 1}}, e.Frames)
+		}
+	})
+
+	t.Run("recursion", func(t *testing.T) {
+		tmpl := Must(Parse("#evaluate($s)", "", ""))
+		err := tmpl.Execute(ioutil.Discard, _m{"s": "#evaluate($s)"})
+		assert.EqualError(t, err, "call depth exceeded at line 1, column 1")
+		var e *Error
+		if assert.True(t, errors.As(err, &e)) {
+			assert.Len(t, e.Frames, DefaultMaxCallDepth)
+			assert.Equal(t, Frame{"#evaluate", "", 1, 1}, e.Frames[0])
+		}
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		tmpl := Must(Parse("\n#evaluate('#if(')", "", ""))
+		err := tmpl.Execute(ioutil.Discard, nil)
+		if assert.Error(t, err) {
+			assert.Regexp(t, "^evaluate: unexpected .*: line 1, column 5 .* at line 2, column 1$", strings.ReplaceAll(err.Error(), "\n", ""))
+		}
+	})
+}
+
+func TestExecuteAlternateValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		tmpl      string
+		context   _m
+		expect    string
+		expectErr string
+	}{
+		{"defined",
+			`${name|'fallback'}`, _m{"name": "jason"}, "jason", ""},
+		{"undefined",
+			`${name|'fallback'}`, nil, "fallback", ""},
+		{"nil",
+			`${name|'fallback'}`, _m{"name": nil}, "fallback", ""},
+		{"nil property",
+			`${user.name|'fallback'}`, _m{"user": map[string]interface{}{"name": nil}}, "fallback", ""},
+		{"undefined base of property",
+			`${user.name|'fallback'}`, nil, "fallback", ""},
+		{"false",
+			`${flag|'fallback'}`, _m{"flag": false}, "fallback", ""},
+		{"empty string",
+			`${name|'fallback'}`, _m{"name": ""}, "fallback", ""},
+		{"empty list",
+			`${list|'fallback'}`, _m{"list": []int{}}, "fallback", ""},
+		{"zero",
+			`${n|'fallback'}`, _m{"n": 0}, "fallback", ""},
+		{"silent",
+			`$!{name|'fallback'}`, nil, "fallback", ""},
+		{"alternate reference",
+			`${name|$other}`, _m{"other": "other"}, "other", ""},
+		{"chained alternate references",
+			`${name|${other|'last'}}`, nil, "last", ""},
+		{"expression",
+			`${n|1 + 2}`, nil, "3", ""},
+		{"list",
+			`${l|[1, 2]}`, nil, "[1, 2]", ""},
+		{"map",
+			`${m|{'a': 1}}`, nil, "{a=1}", ""},
+		{"interpolated string fallback",
+			`${name|"Mr. $last"}`, _m{"last": "Smith"}, "Mr. Smith", ""},
+		{"inside interpolated string",
+			`#set($s = "Hello ${name|'guest'}!")$s`, nil, "Hello guest!", ""},
+		{"macro argument",
+			`#macro(hello $n)Hello $n#end#hello(${name|'guest'})`, nil, "Hello guest", ""},
+		{"expression operand",
+			`#set($x = ${n|1} + 1)$x`, nil, "2", ""},
+		{"undefined fallback",
+			`${name|$other}`, nil, "", "undefined var $other"},
+		{"other errors are not hidden",
+			`${s.nope|'fallback'}`, _m{"s": struct{ A int }{}}, "", "cannot get property nope of struct { A int } value"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			var b bytes.Buffer
+			if assert.NoError(t, err) {
+				err := tmpl.Execute(&b, test.context)
+				if test.expectErr == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, unposErr(err), test.expectErr)
+				}
+				assert.Equal(t, test.expect, b.String())
+			}
+		})
+	}
+}
+
+func TestExecuteBlockMacro(t *testing.T) {
+	tests := []struct {
+		name      string
+		tmpl      string
+		context   _m
+		expect    string
+		expectErr string
+	}{
+		{"without arguments",
+			`#macro(card)<div>$bodyContent</div>#end#@card()Hello $name#end`, _m{"name": "world"}, "<div>Hello world</div>", ""},
+		{"with arguments",
+			`#macro(tag $name)<$name>$bodyContent</$name>#end#@tag('b')bold#end`, nil, "<b>bold</b>", ""},
+		{"body rendered every time",
+			`#macro(twice)#set($i = 1)$bodyContent#set($i = 2)$bodyContent#end#@twice()$i#end`, nil, "12", ""},
+		{"body sees macro context",
+			`#macro(list $items)#foreach($item in $items)$bodyContent#end#end#@list([1..3])[$item]#end`, nil, "[1][2][3]", ""},
+		{"body not rendered",
+			`#macro(skip)skipped#end#@skip()$undefined#end`, nil, "skipped", ""},
+		{"nested block calls",
+			`#macro(b)<b>$bodyContent</b>#end#macro(i)<i>$bodyContent</i>#end#@b()#@i()x#end#end`, nil, "<b><i>x</i></b>", ""},
+		{"body content of outer call",
+			`#macro(inner)[$bodyContent]#end#macro(outer)#@inner()$bodyContent#end#end#@outer()x#end`, nil, "[x]", ""},
+		{"whitespace gobbling",
+			"#macro(card)\n<div>\n$bodyContent</div>\n#end\n#@card()\n  text\n#end\n", nil, "<div>\n  text\n</div>\n", ""},
+		{"inline call has no body",
+			`#macro(card)$bodyContent#end#card()`, nil, "", "undefined var $bodyContent"},
+		{"body is not visible after call",
+			`#macro(card)#end#@card()x#end$bodyContent`, nil, "", "undefined var $bodyContent"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			var b bytes.Buffer
+			if assert.NoError(t, err) {
+				err := tmpl.Execute(&b, test.context)
+				if test.expectErr == "" {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, unposErr(err), test.expectErr)
+				}
+				assert.Equal(t, test.expect, b.String())
+			}
+		})
+	}
+}
+
+func TestParseBlockMacroUndefined(t *testing.T) {
+	_, err := Parse(`#@card()x#end`, "", "")
+	if assert.Error(t, err) {
+		assert.Regexp(t, "^unexpected END,", err.Error())
+	}
+}
+
+// cancelWriter cancels the context after n writes
+type cancelWriter struct {
+	bytes.Buffer
+	n      int
+	cancel context.CancelFunc
+}
+
+func (w *cancelWriter) Write(p []byte) (int, error) {
+	w.n--
+	if w.n == 0 {
+		w.cancel()
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestExecuteContext(t *testing.T) {
+	loader := MapLoader{
+		"row.vm": `[$i]`,
+		"lib.vm": `#macro(row $v)<$v>#end`,
+	}
+	tests := []struct {
+		name      string
+		tmpl      string
+		writes    int
+		expect    string
+		expectErr string
+	}{
+		{"not canceled",
+			`#foreach($i in [1..3])$i#end`, 0, "123", ""},
+		{"loop iteration",
+			"\n#foreach($i in [1..3])$i#end", 2, "\n1", "execution canceled: context canceled at line 2, column 1"},
+		{"nested loop",
+			`#foreach($i in [1..3])#foreach($j in [1..3])$j#end#end`, 2, "12", "execution canceled: context canceled at line 1, column 23"},
+		{"macro call",
+			`#foreach($i in [1..2])$i#row($i)#end`, 1, "1", "execution canceled: context canceled at line 1, column 25"},
+		{"parse",
+			"start\n#parse('row.vm')", 1, "start\n", "execution canceled: context canceled at line 2, column 1"},
+		{"parse in loop",
+			`#set($i = 0)#parse('row.vm')#foreach($i in [1..3])#parse('row.vm')#end`, 4, "[0][1]", "execution canceled: context canceled at line 1, column 29"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := ParseWithLoader(test.tmpl, loader, "lib.vm")
+			if !assert.NoError(t, err) {
+				return
+			}
+			c, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			w := &cancelWriter{n: test.writes, cancel: cancel}
+			err = tmpl.ExecuteContext(c, w, nil)
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectErr)
+				assert.True(t, errors.Is(err, context.Canceled))
+			}
+			assert.Equal(t, test.expect, w.String())
+		})
+	}
+}
+
+func TestExecuteContextDeadline(t *testing.T) {
+	tmpl, err := Parse(`#foreach($i in [1..1000])#foreach($j in [1..1000])$j#end#end`, "", "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	tmpl.WithMaxIterations(-1)
+	c, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = tmpl.ExecuteContext(c, ioutil.Discard, nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestExecuteOrderedMaps(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    string
+		ordered string
+		sorted  string
+	}{
+		{"print", `$m`, "{z=1, a=2, m=3}", "{a=2, m=3, z=1}"},
+		{"foreach", `#foreach($v in $m)$v#end`, "123", "231"},
+		{"views", `$m.keySet() $m.values() $m.entrySet()`,
+			"[z, a, m] [1, 2, 3] [z=1, a=2, m=3]", "[a, m, z] [2, 3, 1] [a=2, m=3, z=1]"},
+		{"put appends", `$m.put('b', 4)$m.put('a', 5)$m`, "2{z=1, a=5, m=3, b=4}", "2{a=5, b=4, m=3, z=1}"},
+		{"set", `#set($m.c = 6)#set($m['d'] = 7)$m.keySet()`, "[z, a, m, c, d]", "[a, c, d, m, z]"},
+		{"remove", `$m.remove('a')$m.put('a', 2)$m`, "2{z=1, m=3, a=2}", "2{a=2, m=3, z=1}"},
+		{"remove value", `$m.values().remove(2)$m.put('a', 0)$m.keySet()`, "true[z, m, a]", "true[a, m, z]"},
+		{"remove entry", `#foreach($e in $m.entrySet())#if($e.key == 'z')$m.entrySet().remove($e)#end#end$m.put('z', 0)$m.keySet()`,
+			"true[a, m, z]", "true[a, m, z]"},
+		{"iterator remove", `#set($it = $m.keySet().iterator())$it.next()$it.remove()$m.put('z', 0)$m`, "z{a=2, m=3, z=0}", "a1{m=3, z=0}"},
+		{"clear", `$m.clear()$m.put('y', 1)$m.put('b', 2)$m`, "{y=1, b=2}", "{b=2, y=1}"},
+		{"put all", `#set($n = {'y': 0})$n.putAll($m)$n`, "{y=0, z=1, a=2, m=3}", "{a=2, m=3, y=0, z=1}"},
+		{"duplicate keys", `#set($m = {'b': 1, 'a': 2, 'b': 3})$m`, "{b=3, a=2}", "{a=2, b=3}"},
+		{"empty literal", `#set($m = {})$m.put('b', 1)$m.put('a', 2)$m`, "{b=1, a=2}", "{a=2, b=1}"},
+		{"nested", `#set($m = {'l': [{'b': 1, 'a': 2}]})$m`, "{l=[{b=1, a=2}]}", "{l=[{a=2, b=1}]}"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(`#set($m = {'z': 1, 'a': 2, 'm': 3})`+test.tmpl, "", "")
+			if !assert.NoError(t, err) {
+				return
+			}
+			for _, ordered := range []bool{true, false} {
+				var b bytes.Buffer
+				err = tmpl.WithOrderedMaps(ordered).Execute(&b, nil)
+				assert.NoError(t, err)
+				expect := test.sorted
+				if ordered {
+					expect = test.ordered
+				}
+				assert.Equal(t, expect, b.String(), "ordered %v", ordered)
+			}
+		})
+	}
+}
+
+func TestExecuteIterationPolicy(t *testing.T) {
+	const cme = "concurrent modification: collection was changed during iteration"
+	tests := []struct {
+		name     string
+		tmpl     string
+		live     string
+		failFast string
+		failErr  string
+		snapshot string
+	}{
+		{"list remove",
+			`#set($l = [1, 2, 3, 4])#foreach($i in $l)$i#if($i == 2)#set($r = $l.remove(3))#end#end $l`,
+			"124 [1, 2, 4]", "12", cme, "1234 [1, 2, 4]"},
+		{"list add",
+			`#set($l = [1, 2, 3])#foreach($i in $l)$i#if($i == 1)#set($r = $l.add(4))#end#end $l`,
+			"1234 [1, 2, 3, 4]", "1", cme, "123 [1, 2, 3, 4]"},
+		{"list remove and add",
+			`#set($l = [1, 2, 3])#foreach($i in $l)$i#if($i == 1)#set($r = $l.remove(3))#set($r = $l.add(5))#end#end`,
+			"125", "1", cme, "123"},
+		{"list set is not a modification",
+			`#set($l = [1, 2, 3])#foreach($i in $l)$i#if($i == 1)#set($l[1] = 5)#end#end`,
+			"153", "153", "", "123"},
+		{"map put",
+			`#set($m = {'a': 1, 'b': 2})#foreach($v in $m)$v#if($v == 1)#set($r = $m.put('c', 3))#end#end $m`,
+			"12 {a=1, b=2, c=3}", "1", cme, "12 {a=1, b=2, c=3}"},
+		{"map put of existing key",
+			`#set($m = {'a': 1, 'b': 2})#foreach($v in $m)$v#if($v == 1)#set($r = $m.put('b', 5))#end#end`,
+			"15", "15", "", "12"},
+		{"map remove",
+			`#set($m = {'a': 1, 'b': 2, 'c': 3})#foreach($e in $m.entrySet())$e.key#if($e.value == 1)#set($r = $m.remove('c'))#end#end`,
+			"abc", "a", cme, "abc"},
+		{"map of the data",
+			`#foreach($v in $d.m)$v#set($r = $d.m.put("x$v", 0))#end`,
+			"12", "1", cme, "12"},
+		{"iterator remove",
+			`#set($l = [1, 2, 3])#set($it = $l.iterator())#foreach($i in $it)$i#if($i == 2)$it.remove()#end#end $l`,
+			"123 [1, 3]", "123 [1, 3]", "", "123 [1, 2]"},
+		{"keys iterator remove",
+			`#set($m = {'a': 1, 'b': 2})#set($it = $m.keySet().iterator())#foreach($k in $it)$k#if($k == 'a')$it.remove()#end#end $m`,
+			"ab {b=2}", "ab {b=2}", "", "ab {a=1}"},
+		{"range",
+			`#foreach($i in [1..3])$i#end`,
+			"123", "123", "", "123"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			if !assert.NoError(t, err) {
+				return
+			}
+			for _, p := range []struct {
+				policy IterationPolicy
+				expect string
+				err    string
+			}{{IterateLive, test.live, ""}, {IterateFailFast, test.failFast, test.failErr}, {IterateSnapshot, test.snapshot, ""}} {
+				var b bytes.Buffer
+				err := tmpl.WithIterationPolicy(p.policy).Execute(&b, _m{"d": _m{"m": map[string]int{"a": 1, "b": 2}}})
+				if p.err == "" {
+					assert.NoError(t, err, "policy %d", p.policy)
+				} else {
+					assert.EqualError(t, unposErr(err), p.err, "policy %d", p.policy)
+					assert.True(t, errors.Is(err, ErrConcurrentModification))
+				}
+				assert.Equal(t, p.expect, b.String(), "policy %d", p.policy)
+			}
+		})
+	}
+}
+
+type keyColor int
+
+func TestExecuteMapKeys(t *testing.T) {
+	data := _m{
+		"users":  map[int]string{10: "x", 2: "y"},
+		"colors": map[keyColor]string{1: "green", 0: "red"},
+		"mixed":  map[interface{}]interface{}{3: "a", 2.5: "b", uint8(1): "c", int64(2): "d", float64(2): "e", "s": "f", false: "g", nil: "h"},
+	}
+	tests := []struct {
+		name      string
+		tmpl      string
+		expect    string
+		expectErr string
+	}{
+		{"literal keys are strings",
+			`#set($m = {1: 'a', '1': 'b', 2.5: 'c', true: 'd'})$m $m.get(1) $m.get('1') $m["1"] $m.get(2.5) $m.get(true) $m.size()`,
+			"{1=b, 2.5=c, true=d} b b b c d 3", ""},
+		{"string literal keys",
+			`#set($m = {'b': 1, 'a': 2})$m $m.a $m.get('b') $m.get(1)`, "{a=2, b=1} 2 1 ", ""},
+		{"number literal keys are found by string",
+			`#set($m = {1: 'a'})$m.get("1")|$m["1"]`, "a|a", ""},
+		{"set by string index in literal with number keys",
+			`#set($m = {1: 'a', 2: 'b'})#set($m["2"] = 'd')$m`, "{1=a, 2=d}", ""},
+		{"number keys of string map",
+			`#set($m = {'1': 'a', '2': 'b'})$m.put(1, 'c')$m.put(3, 'e') $m.remove(2) $m.containsKey(3) $m`, "a b true {1=c, 3=e}", ""},
+		{"remove number key from key set",
+			`#set($m = {'1': 'a', '2': 'b'})$m.keySet().remove(1) $m.keySet().remove(5) $m`, "true false {2=b}", ""},
+		{"put all with number keys",
+			`#set($m = {'1': 'a'})$m.putAll($users)$m`, "{1=a, 10=x, 2=y}", ""},
+		{"int keys", `$users.get(10) $users[2] $users.containsKey(2) $users.containsKey('2') $users`, "x y true false {2=y, 10=x}", ""},
+		{"put int key", `$users.put(3, 'z')$users.keySet()`, "[2, 3, 10]", ""},
+		{"set int key", `#set($users[4] = 'w')$users.get(4)`, "w", ""},
+		{"put wrong key", `$users.put('a', 'z')`, "", "cannot convert key string to int"},
+		{"enum keys", `$colors.get(1) $colors.containsKey(0) $colors.keySet()`, "green true [0, 1]", ""},
+		{"mixed keys order", `$mixed.keySet()`, "[null, 1, 2, 2.0, 2.5, 3, false, s]", ""},
+		{"mixed keys lookup", `$mixed.get(2) $mixed.get(2.0) $mixed.s`, "d e f", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			if !assert.NoError(t, err) {
+				return
+			}
+			var b bytes.Buffer
+			err = tmpl.Execute(&b, data)
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, unposErr(err), test.expectErr)
+			}
+			assert.Equal(t, test.expect, b.String())
+		})
+	}
+}
+
+type argService struct{}
+
+func (argService) Join(sep string, s ...string) string  { return strings.Join(s, sep) }
+func (argService) Count(n ...int) int                   { return len(n) }
+func (argService) IsNil(p *argService) bool             { return p == nil }
+func (argService) Len(l []string, m map[string]int) int { return len(l) + len(m) }
+func (argService) Name(s string) string                 { return s }
+
+func TestExecuteMethodArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		tmpl      string
+		expect    string
+		expectErr string
+	}{
+		{"variadic with fixed argument", `$s.join('-', 'a', 'b') $s.join('-', 'a') [$s.join('-')]`, "a-b a []", ""},
+		{"only variadic", `$s.count() $s.count(1, 2)`, "0 2", ""},
+		{"variadic argument type", `$s.join('-', 1)`, "", "arg 1: not assignable int64 -> string"},
+		{"too few arguments", `$s.join()`, "", "incompatible number of arguments"},
+		{"too many arguments", `$s.name('a', 'b')`, "", "incompatible number of arguments"},
+		{"nil to pointer", `$s.isNil($null)`, "true", ""},
+		{"nil to slice and map", `$s.len($null, $null)`, "0", ""},
+		{"nil to string", `$s.name($null)`, "", "arg 0: not assignable nil -> string"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			if !assert.NoError(t, err) {
+				return
+			}
+			var b bytes.Buffer
+			err = tmpl.Execute(&b, _m{"s": argService{}, "null": nil})
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, unposErr(err), test.expectErr)
+			}
+			assert.Equal(t, test.expect, b.String())
+		})
+	}
+}
+
 func TestExpressions(t *testing.T) {
 	tests := []struct {
 		tmpl   string
@@ -832,8 +1428,8 @@ func TestExpressionFailures(t *testing.T) {
 }
 
 func unposErr(err error) error {
-	if poser, ok := err.(posError); ok {
-		return poser.error
+	if e, ok := err.(*Error); ok {
+		return e.Err
 	}
 	return err
 }
@@ -0,0 +1,59 @@
+package govtl
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FuncsVar is the name of the variable holding functions registered with
+// WithFuncs
+const FuncsVar = "fn"
+
+// funcSet is the value of $fn
+type funcSet map[string]reflect.Value
+
+func (funcSet) kind() string { return "functions" }
+
+// WithFuncs registers Go functions callable from the template as
+// $fn.name(args). Arguments are checked and converted like for methods,
+// functions could be variadic and could return an error as the last result.
+// $fn hides the variable with the same name from the data. It panics if
+// value is not a function or the name is not a valid identifier
+func (t *Template) WithFuncs(funcs map[string]interface{}) *Template {
+	if t.funcs == nil {
+		t.funcs = make(funcSet, len(funcs))
+	}
+	for name, f := range funcs {
+		for i, r := range name {
+			if !isIdent(r) && (i == 0 || !isNum(r)) {
+				panic(fmt.Sprintf("invalid function name %q", name))
+			}
+		}
+		if name == "" {
+			panic("empty function name")
+		}
+		v := reflect.ValueOf(f)
+		if v.Kind() != reflect.Func || v.IsNil() {
+			panic(fmt.Sprintf("%s is %T, not a function", name, f))
+		}
+		t.funcs[name] = v
+	}
+	return t
+}
+
+// callFunc calls registered function name, meth is as written in the
+// template
+func (t *Template) callFunc(fs funcSet, meth string, args ...reflect.Value) (reflect.Value, error) {
+	f, ok := fs[meth]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("undefined function %s", meth)
+	}
+	if err := compatible(f, args...); err != nil {
+		return reflect.Value{}, fmt.Errorf("%s: %w", meth, err)
+	}
+	ret, err := reflectCall(f, args...)
+	if err != nil && len(t.events.method) > 0 {
+		return t.methodException(reflect.ValueOf(fs), meth, err)
+	}
+	return ret, err
+}
@@ -0,0 +1,92 @@
+package govtl
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFuncs = map[string]interface{}{
+	"upper": strings.ToUpper,
+	"sum": func(n ...int) int {
+		var s int
+		for _, i := range n {
+			s += i
+		}
+		return s
+	},
+	"join": func(sep string, s ...string) string { return strings.Join(s, sep) },
+	"div": func(a, b int) (int, error) {
+		if b == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return a / b, nil
+	},
+	"keys": func(m map[string]interface{}) int { return len(m) },
+	"first": func(l []interface{}) interface{} {
+		if len(l) == 0 {
+			return nil
+		}
+		return l[0]
+	},
+	"user":  func() *account { return &account{Name: "u"} },
+	"isNil": func(v interface{}) bool { return v == nil },
+	"noop":  func() {},
+}
+
+func TestWithFuncs(t *testing.T) {
+	tests := []struct {
+		name      string
+		tmpl      string
+		expect    string
+		expectErr string
+	}{
+		{"simple", `$fn.upper('abc') $fn.upper("x$s")`, "ABC XY", ""},
+		{"variadic", `$fn.sum() $fn.sum(1) $fn.sum(1, 2, 3) $fn.join('-', 'a', 'b')`, "0 1 6 a-b", ""},
+		{"result with error", `$fn.div(6, 3)`, "2", ""},
+		{"error", `$fn.div(1, 0)`, "", "division by zero"},
+		{"list and map arguments", `$fn.keys({'a': 1, 'b': 2}) $fn.first([3, 4])`, "2 3", ""},
+		{"go values", `$fn.keys($m) $fn.join('', $l[0])`, "1 x", ""},
+		{"nil argument", `$fn.isNil($fn.noop())`, "true", ""},
+		{"result is wrapped", `$fn.user().name $fn.upper('a').length()`, "u 1", ""},
+		{"in expressions", `#set($x = $fn.sum(1, 2) * 2)#if($fn.sum(1) == 1)$x#end`, "6", ""},
+		{"shadows data", `$fn.sum(1)`, "1", ""},
+		{"undefined", `$fn.nope()`, "", "undefined function nope"},
+		{"arity", `$fn.div(1)`, "", "div: incompatible number of arguments"},
+		{"argument type", `$fn.upper(1.5)`, "", "upper: arg 0: not assignable float64 -> string"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			require.NoError(t, err)
+			var b bytes.Buffer
+			err = tmpl.WithFuncs(testFuncs).Execute(&b, _m{"s": "y", "m": _m{"k": 1}, "l": []string{"x"}, "fn": "data"})
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, unposErr(err), test.expectErr)
+			}
+			assert.Equal(t, test.expect, b.String())
+		})
+	}
+}
+
+func TestWithFuncsInvalid(t *testing.T) {
+	tmpl := Must(Parse(``, "", ""))
+	assert.PanicsWithValue(t, "f is string, not a function", func() { tmpl.WithFuncs(map[string]interface{}{"f": "x"}) })
+	assert.PanicsWithValue(t, `invalid function name "a-b"`, func() { tmpl.WithFuncs(map[string]interface{}{"a-b": strings.ToUpper}) })
+	assert.PanicsWithValue(t, "empty function name", func() { tmpl.WithFuncs(map[string]interface{}{"": strings.ToUpper}) })
+}
+
+func TestCheckTypeFuncs(t *testing.T) {
+	tmpl := Must(Parse(`$fn.upper('a').length() $fn.div(1) $fn.nope() $fn.user().nmae`, "", ""))
+	err := tmpl.WithFuncs(testFuncs).CheckTypes(map[string]reflect.Type{})
+	assert.EqualError(t, err, "div: incompatible number of arguments at line 1, column 29\n"+
+		"undefined function nope at line 1, column 40\n"+
+		"cannot get property nmae of *govtl.account value at line 1, column 58")
+}
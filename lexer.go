@@ -3,13 +3,20 @@ package govtl
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strings"
+	"unicode/utf8"
 )
 
 type Token struct {
 	token   int
 	literal string
 	line    int
+	col     int
+}
+
+func (t Token) pos() Pos {
+	return Pos{t.line, t.col}
 }
 
 type Lexer struct {
@@ -18,8 +25,19 @@ type Lexer struct {
 	line, col int
 	states    []lexState
 	macros    map[string]bool
-	result    []Node
-	err       error
+	// custom directives known to the lexer
+	directives map[string]Directive
+	result     []Node
+	errs       SyntaxErrors
+	// name of the template, used for macros defined in it
+	name string
+	// position up to which line and col are calculated
+	counted int
+	// position of the current token
+	tok Pos
+	// current token and the state of the parser when it was read, they
+	// describe syntax errors found by the parser
+	char, yys int
 }
 
 var directives = map[string]int{
@@ -41,7 +59,9 @@ var directives = map[string]int{
 func (l *Lexer) Init(s string) {
 	l.data = []byte(s)
 	l.line = 1
+	l.col = 1
 	l.macros = make(map[string]bool)
+	l.directives = make(map[string]Directive)
 }
 
 type lexState int
@@ -79,19 +99,26 @@ const (
 )
 
 func (l *Lexer) Lex(lval *yySymType) int {
+	l.yys = lval.yys
+	l.char = l.lex(lval)
+	return l.char
+}
+
+func (l *Lexer) lex(lval *yySymType) int {
+	l.prev = l.pos
+	l.tok = Pos{l.line, l.col}
 	if l.Peek(0) == EOF {
 		return 0
 	}
-	l.prev = l.pos
 	defer func() {
 		if l.pos > len(l.data) {
 			l.pos = len(l.data)
 		}
-		l.line += bytes.Count(l.data[l.prev:l.pos], []byte("\n"))
+		l.advance()
 	}()
 	switch l.state() {
 	case sText:
-		line := l.line
+		line, col := l.line, l.col
 		text := l.ScanText("#$")
 		var hadComment bool
 		if l.Peek(0) == '#' && l.Peek(1) == '#' {
@@ -100,7 +127,7 @@ func (l *Lexer) Lex(lval *yySymType) int {
 			hadComment = true
 		}
 		if l.pos > l.prev && (len(text) > 0 || hadComment) {
-			lval.t = Token{token: TEXT, literal: text, line: line}
+			lval.t = Token{token: TEXT, literal: text, line: line, col: col}
 			return TEXT
 		}
 		switch l.Peek(0) {
@@ -115,7 +142,7 @@ func (l *Lexer) Lex(lval *yySymType) int {
 				return l.Lex(lval)
 			default:
 				// return self as text
-				lval.t = Token{token: TEXT, literal: string(l.ScanByte()), line: l.line}
+				lval.t = Token{token: TEXT, literal: string(l.ScanByte()), line: l.line, col: l.col}
 				return TEXT
 			}
 		case '#':
@@ -132,51 +159,81 @@ func (l *Lexer) Lex(lval *yySymType) int {
 				d := l.ScanIdentifier()
 				if directive, ok := directives[d]; ok && l.Peek(0) == '}' {
 					l.Skip(1)
-					lval.t = Token{token: directive, line: l.line}
+					lval.t = Token{token: directive, line: l.line, col: l.col}
 					if directive != END && directive != ELSE {
 						l.SkipWhitespace()
 						pushState(l, sDir)
 					}
 					return directive
 				}
+				if _, ok := l.directives[d]; ok && l.Peek(0) == '}' {
+					l.Skip(1)
+					return l.customDirective(lval, d)
+				}
 				if l.macros[d] && l.Peek(0) == '}' {
 					l.Skip(1)
 					l.SkipWhitespace()
 					pushState(l, sDir)
-					lval.t = Token{token: MACROCALL, literal: d, line: l.line}
+					lval.t = Token{token: MACROCALL, literal: d, line: l.line, col: l.col}
 					return MACROCALL
 				}
-				lval.t = Token{token: TEXT, literal: "#{" + d, line: l.line}
+				lval.t = Token{token: TEXT, literal: "#{" + d, line: l.line, col: l.col}
 				return TEXT
 			case '[':
 				if l.Peek(2) == '[' {
 					// unparsed content until ]]#
-				} else {
-					// just text
+					line, col := l.line, l.col
+					l.Skip(3)
+					start := l.pos
+					l.ScanComment("]]#")
+					text := string(l.data[start:l.pos])
+					if l.pos == len(l.data) {
+						e := l.errorAt(Pos{line, col}, "unexpected $end, expected ]]#")
+						e.Unexpected, e.Expected = "$end", []string{"]]#"}
+					}
+					// eat ending ]]#
+					l.Skip(3)
+					lval.t = Token{token: TEXT, literal: text, line: line, col: col}
+					return TEXT
+				}
+				// otherwise just text
+			case '@':
+				l.Skip(2)
+				d := l.ScanIdentifier()
+				if l.macros[d] {
+					l.SkipWhitespace()
+					pushState(l, sDir)
+					lval.t = Token{token: BLOCKMACROCALL, literal: d, line: l.line, col: l.col}
+					return BLOCKMACROCALL
 				}
+				lval.t = Token{token: TEXT, literal: "#@" + d, line: l.line, col: l.col}
+				return TEXT
 			}
 			// directive
 			l.Skip(1)
 			d := l.ScanIdentifier()
 			if directive, ok := directives[d]; ok {
-				lval.t = Token{token: directive, line: l.line}
+				lval.t = Token{token: directive, line: l.line, col: l.col}
 				if directive != END && directive != ELSE {
 					l.SkipWhitespace()
 					pushState(l, sDir)
 				}
 				return directive
 			}
+			if _, ok := l.directives[d]; ok {
+				return l.customDirective(lval, d)
+			}
 			if l.macros[d] {
 				l.SkipWhitespace()
 				pushState(l, sDir)
-				lval.t = Token{token: MACROCALL, literal: d, line: l.line}
+				lval.t = Token{token: MACROCALL, literal: d, line: l.line, col: l.col}
 				return MACROCALL
 			}
 			// or just text
-			lval.t = Token{token: TEXT, literal: "#" + d, line: l.line}
+			lval.t = Token{token: TEXT, literal: "#" + d, line: l.line, col: l.col}
 			return TEXT
 		}
-		lval.t = Token{token: TEXT, literal: "", line: l.line}
+		lval.t = Token{token: TEXT, literal: "", line: l.line, col: l.col}
 		return TEXT
 	case sDir:
 		switch l.Peek(0) {
@@ -187,6 +244,12 @@ func (l *Lexer) Lex(lval *yySymType) int {
 			return l.Lex(lval)
 		}
 	case sFormal:
+		if l.Peek(0) == '|' {
+			// alternate value is an expression up to the closing }
+			popState(l)
+			pushState(l, sExpr)
+			return int(l.ScanByte())
+		}
 		if l.Peek(0) != '}' {
 			break
 		}
@@ -204,12 +267,14 @@ func (l *Lexer) Lex(lval *yySymType) int {
 		}
 	case sExpr:
 		l.SkipWhitespace()
+		l.advance()
+		l.prev, l.tok = l.pos, Pos{l.line, l.col}
 		p := l.Peek(0)
 		switch p {
-		case '(', '[':
+		case '(', '[', '{':
 			pushState(l, sExpr)
 			return int(l.ScanByte())
-		case ')', ']':
+		case ')', ']', '}':
 			popState(l)
 			return int(l.ScanByte())
 		case '\'':
@@ -218,7 +283,7 @@ func (l *Lexer) Lex(lval *yySymType) int {
 			s := l.ScanString('\'')
 			// skip '
 			l.Skip(1)
-			lval.t = Token{token: STRING, literal: s, line: l.line}
+			lval.t = Token{token: STRING, literal: s, line: l.line, col: l.col}
 			return STRING
 		case '"':
 			pushState(l, sString)
@@ -227,12 +292,12 @@ func (l *Lexer) Lex(lval *yySymType) int {
 			pushState(l, sVar)
 		case '.', '=', '!', '<', '>', '|', '&':
 			if op := l.ScanOp(); op != "" {
-				lval.t = Token{token: ops[op], literal: altOps[op], line: l.line}
+				lval.t = Token{token: ops[op], literal: altOps[op], line: l.line, col: l.col}
 				return ops[op]
 			}
 			if l.state() == sRef && p == '.' && !isIdent(l.Peek(1)) {
 				popState(l)
-				lval.t = Token{token: TEXT, literal: l.ScanText("#$"), line: l.line}
+				lval.t = Token{token: TEXT, literal: l.ScanText("#$"), line: l.line, col: l.col}
 				return TEXT
 			}
 			return int(l.ScanByte())
@@ -242,7 +307,7 @@ func (l *Lexer) Lex(lval *yySymType) int {
 			case "ge", "le", "gt", "lt", "eq", "ne", "and", "or", "not":
 				prev := l.Peek(-len(ident) - 1)
 				if prev == ' ' || prev == '\t' || prev == '\n' || prev == '(' {
-					lval.t = Token{token: ops[ident], literal: ident, line: l.line}
+					lval.t = Token{token: ops[ident], literal: ident, line: l.line, col: l.col}
 					return ops[ident]
 				}
 			case "in":
@@ -253,12 +318,12 @@ func (l *Lexer) Lex(lval *yySymType) int {
 			case "true", "false":
 				prev := l.Peek(-len(ident) - 1)
 				if prev != '.' && prev != '$' {
-					lval.t = Token{token: BOOLEAN, literal: ident, line: l.line}
+					lval.t = Token{token: BOOLEAN, literal: ident, line: l.line, col: l.col}
 					return BOOLEAN
 				}
 			}
 			if ident != "" {
-				lval.t = Token{token: IDENTIFIER, literal: ident, line: l.line}
+				lval.t = Token{token: IDENTIFIER, literal: ident, line: l.line, col: l.col}
 				return IDENTIFIER
 			}
 		}
@@ -282,7 +347,7 @@ func (l *Lexer) Lex(lval *yySymType) int {
 				}
 				l.ScanInt()
 			}
-			lval.t = Token{token: tok, literal: string(l.data[start:l.pos]), line: l.line}
+			lval.t = Token{token: tok, literal: string(l.data[start:l.pos]), line: l.line, col: l.col}
 			return tok
 		}
 	case sVar:
@@ -302,7 +367,7 @@ func (l *Lexer) Lex(lval *yySymType) int {
 				case '(':
 					tok = METHOD
 				}
-				lval.t = Token{token: tok, literal: ident, line: l.line}
+				lval.t = Token{token: tok, literal: ident, line: l.line, col: l.col}
 				return tok
 			}
 			popState(l)
@@ -314,7 +379,7 @@ func (l *Lexer) Lex(lval *yySymType) int {
 	case sString:
 		text := l.ScanText("$\"")
 		if l.pos > l.prev {
-			lval.t = Token{token: TEXT, literal: text, line: l.line}
+			lval.t = Token{token: TEXT, literal: text, line: l.line, col: l.col}
 			return TEXT
 		}
 		switch l.Peek(0) {
@@ -330,7 +395,7 @@ func (l *Lexer) Lex(lval *yySymType) int {
 				}
 				pushState(l, sRef)
 			default:
-				lval.t = Token{token: TEXT, literal: string(l.ScanByte()), line: l.line}
+				lval.t = Token{token: TEXT, literal: string(l.ScanByte()), line: l.line, col: l.col}
 				return TEXT
 			}
 		}
@@ -338,14 +403,83 @@ func (l *Lexer) Lex(lval *yySymType) int {
 	if l.Peek(0) == EOF {
 		return 0
 	}
-	return int(l.ScanByte())
+	c := int(l.ScanByte())
+	lval.t = Token{token: c, line: l.line, col: l.col}
+	return c
+}
+
+// advance updates line and col up to the current position
+func (l *Lexer) advance() {
+	if l.pos <= l.counted {
+		return
+	}
+	scanned := l.data[l.counted:l.pos]
+	l.line += bytes.Count(scanned, []byte("\n"))
+	if i := bytes.LastIndexByte(scanned, '\n'); i >= 0 {
+		l.col = 1 + utf8.RuneCount(scanned[i+1:])
+	} else {
+		l.col += utf8.RuneCount(scanned)
+	}
+	l.counted = l.pos
 }
 
 func (l *Lexer) Pos() string {
 	return fmt.Sprint(l.pos)
 }
 
+// customDirective returns token for the call of the registered directive d
+func (l *Lexer) customDirective(lval *yySymType, d string) int {
+	token := DIRECTIVECALL
+	if l.directives[d].Spec().Body {
+		token = BLOCKDIRECTIVECALL
+	}
+	l.SkipWhitespace()
+	pushState(l, sDir)
+	lval.t = Token{token: token, literal: d, line: l.line, col: l.col}
+	return token
+}
+
 func (l *Lexer) Error(s string) {
+	e := l.errorAt(l.tok, s)
+	e.Unexpected, e.Expected = l.unexpected(), l.expected()
+	// continue in text mode, so the parser could resync at the next
+	// directive
+	l.states = l.states[:0]
+}
+
+// unexpected returns the name of the current token
+func (l *Lexer) unexpected() string {
+	if l.char <= 0 {
+		return yySymName(yyEofCode)
+	}
+	if ls := yyTokenLiteralStrings[l.char]; ls != "" {
+		return ls
+	}
+	return yySymName(l.char)
+}
+
+// expected returns tokens and grammar rules for which the parser has an
+// action in the state the current token was read in, rules go first
+func (l *Lexer) expected() []string {
+	var rules, tokens []string
+	for x, action := range yyParseTab[l.yys] {
+		name := yySymNames[x]
+		switch {
+		case action == 0 || name == "error" || name != "$end" && strings.HasPrefix(name, "$"):
+		case name[0] >= 'a' && name[0] <= 'z':
+			rules = append(rules, name)
+		default:
+			tokens = append(tokens, name)
+		}
+	}
+	sort.Strings(rules)
+	sort.Strings(tokens)
+	return append(rules, tokens...)
+}
+
+// errorAt records syntax error at pos, context is taken around the current
+// token
+func (l *Lexer) errorAt(pos Pos, s string) *SyntaxError {
 	start := l.prev - 20
 	if start < 0 {
 		start = 0
@@ -354,11 +488,14 @@ func (l *Lexer) Error(s string) {
 	if end > len(l.data) {
 		end = len(l.data)
 	}
-	pos := l.prev
-	if pos > len(l.data) {
-		pos = len(l.data)
+	offset := l.prev
+	if offset > len(l.data) {
+		offset = len(l.data)
 	}
-	l.err = fmt.Errorf("%s: line %d (byte %d) (%s|%s)\n", s, l.line, l.prev, l.data[start:pos], l.data[pos:end])
+	e := &SyntaxError{Name: l.name, Line: pos.line, Column: pos.col, msg: s,
+		before: string(l.data[start:offset]), after: string(l.data[offset:end])}
+	l.errs = append(l.errs, e)
+	return e
 }
 
 func (l *Lexer) state() lexState {
@@ -368,9 +505,12 @@ func (l *Lexer) state() lexState {
 	return l.states[len(l.states)-1]
 }
 
-func addMacro(l interface{}, name string) {
+func addMacro(l interface{}, tok Token) {
 	lex := l.(*Lexer)
-	lex.macros[name] = true
+	if _, ok := lex.directives[tok.literal]; ok {
+		lex.errorAt(tok.pos(), fmt.Sprintf("macro #%s conflicts with custom directive", tok.literal))
+	}
+	lex.macros[tok.literal] = true
 }
 
 func eatWSend(t string) string {
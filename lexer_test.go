@@ -15,6 +15,8 @@ func TestLex(t *testing.T) {
 		`$x${x} normal then formal`,
 		`$!{x}$x silent formal, then normal`,
 		`#set($x = 2 > 0 && 3-2==1)`,
+		`#[[ $x #set( ]]# unparsed`,
+		`#[[ unterminated $x`,
 	}
 	for _, test := range tests {
 		l := &Lexer{}
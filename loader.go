@@ -0,0 +1,148 @@
+package govtl
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Loader provides content of templates by name for #include, #parse and
+// macro library.
+type Loader interface {
+	Load(name string) ([]byte, error)
+}
+
+// ForbiddenPathError is returned when template name resolves outside of the
+// loader root, e.g. it is absolute, contains .. or symlink to other directory
+type ForbiddenPathError struct {
+	Name string
+}
+
+func (e *ForbiddenPathError) Error() string {
+	return fmt.Sprintf("template %s is outside of the root", e.Name)
+}
+
+// DirLoader loads templates from the Root directory on the filesystem. Names
+// resolving outside of the Root are rejected unless they are inside one of
+// Allow directories, absolute names are always rejected
+type DirLoader struct {
+	Root  string
+	Allow []string
+}
+
+func (d DirLoader) Load(name string) ([]byte, error) {
+	p, err := d.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(p)
+}
+
+func (d DirLoader) Stat(name string) (string, time.Time, error) {
+	p, err := d.resolve(name)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	fi, err := os.Stat(p)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return p, fi.ModTime(), nil
+}
+
+func (d DirLoader) resolve(name string) (string, error) {
+	var dirs []string
+	for _, dir := range append([]string{d.Root}, d.Allow...) {
+		dir, err := filepath.Abs(dir)
+		if err != nil {
+			return "", err
+		}
+		dirs = append(dirs, dir)
+	}
+	if filepath.IsAbs(name) {
+		return "", &ForbiddenPathError{name}
+	}
+	p := filepath.Join(dirs[0], name)
+	if !within(dirs, p) {
+		return "", &ForbiddenPathError{name}
+	}
+	real, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		return "", err
+	}
+	for i := range dirs {
+		if dir, err := filepath.EvalSymlinks(dirs[i]); err == nil {
+			dirs[i] = dir
+		}
+	}
+	if !within(dirs, real) {
+		return "", &ForbiddenPathError{name}
+	}
+	return real, nil
+}
+
+// within checks whether p is inside of one of the dirs
+func within(dirs []string, p string) bool {
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(dir, p)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// MapLoader loads templates from memory
+type MapLoader map[string]string
+
+func (m MapLoader) Load(name string) ([]byte, error) {
+	data, ok := m[name]
+	if !ok {
+		return nil, notFound(name)
+	}
+	return []byte(data), nil
+}
+
+// ChainLoader tries loaders in order, falling back to the next one if
+// template is not found
+type ChainLoader []Loader
+
+func (c ChainLoader) Load(name string) ([]byte, error) {
+	for _, l := range c {
+		data, err := l.Load(name)
+		if err == nil || !errors.Is(err, os.ErrNotExist) {
+			return data, err
+		}
+	}
+	return nil, notFound(name)
+}
+
+func (c ChainLoader) Stat(name string) (string, time.Time, error) {
+	for i, l := range c {
+		var (
+			key     = name
+			modTime time.Time
+			err     error
+		)
+		if sl, ok := l.(StatLoader); ok {
+			key, modTime, err = sl.Stat(name)
+		} else {
+			_, err = l.Load(name)
+		}
+		if err == nil {
+			return fmt.Sprintf("%d:%s", i, key), modTime, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", time.Time{}, err
+		}
+	}
+	return "", time.Time{}, notFound(name)
+}
+
+func notFound(name string) error {
+	return fmt.Errorf("template %s: %w", name, os.ErrNotExist)
+}
@@ -0,0 +1,35 @@
+//go:build go1.16
+// +build go1.16
+
+package govtl
+
+import (
+	"io/fs"
+	"path"
+	"time"
+)
+
+// FSLoader loads templates from fs.FS, e.g. embedded into the binary
+type FSLoader struct {
+	fs.FS
+}
+
+func (l FSLoader) Load(name string) ([]byte, error) {
+	p := path.Clean(name)
+	if !fs.ValidPath(p) {
+		return nil, &ForbiddenPathError{name}
+	}
+	return fs.ReadFile(l.FS, p)
+}
+
+func (l FSLoader) Stat(name string) (string, time.Time, error) {
+	p := path.Clean(name)
+	if !fs.ValidPath(p) {
+		return "", time.Time{}, &ForbiddenPathError{name}
+	}
+	fi, err := fs.Stat(l.FS, p)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return p, fi.ModTime(), nil
+}
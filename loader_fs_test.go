@@ -0,0 +1,45 @@
+//go:build go1.16
+// +build go1.16
+
+package govtl
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFSLoader(t *testing.T) {
+	fsys := fstest.MapFS{
+		"lib.vm":          {Data: []byte(`#macro(hello $n)Hello $n#end`)},
+		"partials/row.vm": {Data: []byte(`#hello($name)`)},
+	}
+	tmpl, err := ParseWithLoader(`#parse("partials/row.vm") #include("./partials/row.vm")`, FSLoader{fsys}, "lib.vm")
+	require.NoError(t, err)
+	var b bytes.Buffer
+	require.NoError(t, tmpl.Execute(&b, map[string]interface{}{"name": "world"}))
+	assert.Equal(t, "Hello world #hello($name)", b.String())
+
+	tmpl, err = ParseName("include.vm", FSLoader{os.DirFS("templates")}, "VM_global_library.vm")
+	require.NoError(t, err)
+	b.Reset()
+	assert.NoError(t, tmpl.Execute(&b, nil))
+	assert.Contains(t, b.String(), "This is included text!")
+}
+
+func TestFSLoaderTraversal(t *testing.T) {
+	loader := FSLoader{fstest.MapFS{"a.vm": {Data: []byte("a")}}}
+	for _, name := range []string{"../a.vm", "/a.vm", "x/../../a.vm"} {
+		_, err := loader.Load(name)
+		var perr *ForbiddenPathError
+		assert.True(t, errors.As(err, &perr), name)
+	}
+	data, err := loader.Load("x/../a.vm")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", string(data))
+}
@@ -0,0 +1,140 @@
+package govtl
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoaders(t *testing.T) {
+	mem := MapLoader{
+		"lib.vm":        `#macro(hello $n)Hello $n#end`,
+		"include.vm":    `$name`,
+		"parse.vm":      `#hello($name)`,
+		"test.txt":      `from memory`,
+		"overridden.vm": `memory`,
+	}
+	broken := MapLoader{"broken.vm": `#if(`}
+	tests := []struct {
+		name      string
+		loader    Loader
+		lib       string
+		tmpl      string
+		expect    string
+		expectErr string
+	}{
+		{"map include",
+			mem, "lib.vm", `#include("include.vm")`, "$name", ""},
+		{"map parse uses library",
+			mem, "lib.vm", `#parse("parse.vm")`, "Hello world", ""},
+		{"map library",
+			mem, "lib.vm", `#hello('you')`, "Hello you", ""},
+		{"map not found",
+			mem, "lib.vm", `#include("nope.vm")`, "", "template nope.vm: file does not exist"},
+		{"dir include",
+			DirLoader{Root: "templates"}, "", `#include("subdir/test.txt")`, "This is included text!\n\n", ""},
+		{"chain falls back",
+			ChainLoader{MapLoader{"lib.vm": mem["lib.vm"]}, DirLoader{Root: "templates"}}, "lib.vm", `#include("subdir/test.txt")#hello('x')`, "This is included text!\n\nHello x", ""},
+		{"chain prefers first",
+			ChainLoader{mem, DirLoader{Root: "templates"}}, "lib.vm", `#include("test.txt")`, "from memory", ""},
+		{"chain not found",
+			ChainLoader{mem, DirLoader{Root: "templates"}}, "lib.vm", `#include("nope.vm")`, "", "template nope.vm: file does not exist"},
+		{"chain stops on parse error",
+			ChainLoader{broken, mem}, "lib.vm", `#parse("broken.vm")`, "", "unexpected $end"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := ParseWithLoader(test.tmpl, test.loader, test.lib)
+			require.NoError(t, err)
+			var b bytes.Buffer
+			err = tmpl.Execute(&b, map[string]interface{}{"name": "world"})
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, unposErr(err).Error(), test.expectErr)
+			}
+			assert.Equal(t, test.expect, b.String())
+		})
+	}
+}
+
+func TestParseName(t *testing.T) {
+	tmpl, err := ParseName("parse.vm", MapLoader{"parse.vm": "#hello('x')", "lib.vm": "#macro(hello $n)Hi $n#end"}, "lib.vm")
+	require.NoError(t, err)
+	var b bytes.Buffer
+	require.NoError(t, tmpl.Execute(&b, nil))
+	assert.Equal(t, "Hi x", b.String())
+
+	_, err = ParseName("nope.vm", MapLoader{}, "")
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	_, err = ParseName("parse.vm", MapLoader{"parse.vm": ""}, "lib.vm")
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestDirLoaderTraversal(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "govtl")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	root := filepath.Join(tmp, "root")
+	shared := filepath.Join(tmp, "shared")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sub"), 0755))
+	require.NoError(t, os.MkdirAll(shared, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(root, "sub", "ok.vm"), []byte("ok"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(tmp, "secret.txt"), []byte("secret"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(shared, "shared.vm"), []byte("shared"), 0644))
+	require.NoError(t, os.Symlink(filepath.Join(tmp, "secret.txt"), filepath.Join(root, "link.txt")))
+	require.NoError(t, os.Symlink(shared, filepath.Join(root, "shared")))
+	require.NoError(t, os.Symlink(filepath.Join(root, "sub", "ok.vm"), filepath.Join(root, "inner.vm")))
+
+	tests := []struct {
+		name      string
+		allow     []string
+		file      string
+		expect    string
+		forbidden bool
+	}{
+		{"inside root", nil, "sub/ok.vm", "ok", false},
+		{"dots inside root", nil, "sub/../sub/./ok.vm", "ok", false},
+		{"symlink inside root", nil, "inner.vm", "ok", false},
+		{"parent dir", nil, "../secret.txt", "", true},
+		{"parent dir from subdir", nil, "sub/../../secret.txt", "", true},
+		{"absolute", nil, filepath.Join(tmp, "secret.txt"), "", true},
+		{"absolute inside root", nil, filepath.Join(root, "sub", "ok.vm"), "", true},
+		{"symlink outside root", nil, "link.txt", "", true},
+		{"symlinked dir outside root", nil, "shared/shared.vm", "", true},
+		{"allowed symlinked dir", []string{shared}, "shared/shared.vm", "shared", false},
+		{"allowed parent dir", []string{shared}, "../shared/shared.vm", "shared", false},
+		{"allowed absolute", []string{shared}, filepath.Join(shared, "shared.vm"), "", true},
+		{"not allowed", []string{shared}, "../secret.txt", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loader := DirLoader{Root: root, Allow: test.allow}
+			for _, directive := range []string{"include", "parse"} {
+				tmpl, err := ParseWithLoader("#"+directive+"($file)", loader, "")
+				require.NoError(t, err)
+				var b bytes.Buffer
+				err = tmpl.Execute(&b, map[string]interface{}{"file": test.file})
+				if test.forbidden {
+					var perr *ForbiddenPathError
+					if assert.True(t, errors.As(err, &perr), "%s: %v", directive, err) {
+						assert.Equal(t, test.file, perr.Name)
+					}
+				} else {
+					assert.NoError(t, err, directive)
+				}
+				assert.Equal(t, test.expect, b.String(), directive)
+			}
+		})
+	}
+
+	_, err = DirLoader{Root: root}.Load("nope.vm")
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+}
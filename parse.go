@@ -2,7 +2,6 @@ package govtl
 
 import (
 	"io/ioutil"
-	"path/filepath"
 	"reflect"
 	"strings"
 	"sync"
@@ -18,15 +17,24 @@ type methodIdx struct {
 }
 
 type Template struct {
-	root, lib     string
+	name          string
+	loader        Loader
+	lib           string
 	tree          []Node
 	macros        map[string]*MacroNode
+	cache         *TemplateCache
+	escape        EscapeMode
+	events        eventHandlers
+	policy        *SecurityPolicy
 	typeCache     map[reflect.Type][]methodIdx
 	cacheMutex    sync.Mutex
 	maxCallDepth  int
 	maxIterations int
 	maxArraySize  int
-	pos           Pos
+	orderedMaps   bool
+	iteration     IterationPolicy
+	funcs         funcSet
+	directives    map[string]Directive
 }
 
 func Must(t *Template, err error) *Template {
@@ -42,31 +50,74 @@ func ParseFile(f, root, lib string) (*Template, error) {
 		return nil, err
 	}
 
-	return Parse(string(data), root, lib)
+	return parseTemplate(f, string(data), DirLoader{Root: root}, lib, nil)
 }
 
 func Parse(vtl, root, lib string) (*Template, error) {
+	return ParseWithLoader(vtl, DirLoader{Root: root}, lib)
+}
+
+// ParseName loads template name and lib macro library with loader and parses
+// them
+func ParseName(name string, loader Loader, lib string) (*Template, error) {
+	data, err := loader.Load(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTemplate(name, string(data), loader, lib, nil)
+}
+
+// ParseWithLoader parses vtl, #include, #parse and lib macro library are
+// loaded with loader
+func ParseWithLoader(vtl string, loader Loader, lib string) (*Template, error) {
+	return parseTemplate("", vtl, loader, lib, nil)
+}
+
+func parseTemplate(name, vtl string, loader Loader, lib string, directives map[string]Directive) (*Template, error) {
 	macros := make(map[string]*MacroNode)
 	if lib != "" {
-		libAST, err := ParseFile(filepath.Join(root, lib), root, "")
+		data, err := loader.Load(lib)
+		if err != nil {
+			return nil, err
+		}
+		libAST, err := parseTemplate(lib, string(data), loader, "", directives)
 		if err != nil {
 			return nil, err
 		}
-		libAST.Execute(ioutil.Discard, nil)
-		macros = libAST.macros
+		ctx := NewContext()
+		ctx.name = lib
+		libAST._execute(ioutil.Discard, libAST.tree, ctx)
+		macros = ctx.macros
 	}
+	ast, err := parse(name, vtl, directives, macros)
+	if err != nil {
+		return nil, err
+	}
+	return &Template{name, loader, lib, ast, macros, NewTemplateCache(false), EscapeNone, eventHandlers{}, nil, make(map[reflect.Type][]methodIdx), sync.Mutex{}, DefaultMaxCallDepth, DefaultMaxIterations, DefaultMaxArrayRenderSize, false, IterateLive, nil, directives}, nil
+}
+
+// parse builds AST for template name from vtl, custom directives and names
+// of all passed macros are known to the lexer
+func parse(name, vtl string, directives map[string]Directive, macros ...map[string]*MacroNode) ([]Node, error) {
 	l := new(Lexer)
 	l.Init(vtl)
-	for k := range macros {
-		l.macros[k] = true
+	l.name = name
+	for _, m := range macros {
+		for k := range m {
+			l.macros[k] = true
+		}
+	}
+	if directives != nil {
+		l.directives = directives
 	}
-	ret := yyParse(l)
-	if ret != 0 {
-		return nil, l.err
+	yyParse(l)
+	if len(l.errs) > 0 {
+		return nil, l.errs
 	}
 	ast := l.result
 	gobble(ast, false)
-	return &Template{root, lib, ast, macros, make(map[reflect.Type][]methodIdx), sync.Mutex{}, DefaultMaxCallDepth, DefaultMaxIterations, DefaultMaxArrayRenderSize, Pos{}}, nil
+	return ast, nil
 }
 
 func (t *Template) WithMaxCallDepth(n int) *Template {
@@ -84,6 +135,22 @@ func (t *Template) WithMaxArrayRenderSize(n int) *Template {
 	return t
 }
 
+// WithOrderedMaps makes map literals of the template preserve insertion order
+// of the keys, like LinkedHashMap in Velocity. By default maps are iterated
+// in sorted key order
+func (t *Template) WithOrderedMaps(on bool) *Template {
+	t.orderedMaps = on
+	return t
+}
+
+// WithTemplateCache sets cache for templates used by #parse, nil disables
+// caching. Templates sharing the cache should be parsed with the same
+// custom directives
+func (t *Template) WithTemplateCache(c *TemplateCache) *Template {
+	t.cache = c
+	return t
+}
+
 func gobble(ast []Node, nested bool) {
 	// Text Directive Text
 	// Directive Text
@@ -1,7 +1,10 @@
 package govtl
 
 import (
+	"errors"
+	"reflect"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -46,39 +49,52 @@ func TestParse(t *testing.T) {
 
 		{"short var reference",
 			"$var_1",
-			[]Node{&VarNode{&RefNode{"var_1"}, nil, false, Pos{1}}},
+			[]Node{&VarNode{&RefNode{"var_1"}, nil, false, nil, Pos{1, 1}}},
 		},
 		{"short and formal reference",
 			"$var${var}",
-			[]Node{&VarNode{&RefNode{"var"}, nil, false, Pos{1}}, &VarNode{&RefNode{"var"}, nil, false, Pos{1}}},
+			[]Node{&VarNode{&RefNode{"var"}, nil, false, nil, Pos{1, 1}}, &VarNode{&RefNode{"var"}, nil, false, nil, Pos{1, 5}}},
 		},
 		{"formal var reference",
 			"${var_1}",
-			[]Node{&VarNode{&RefNode{"var_1"}, nil, false, Pos{1}}},
+			[]Node{&VarNode{&RefNode{"var_1"}, nil, false, nil, Pos{1, 1}}},
 		},
 		{"silent short var reference",
 			"$!var",
-			[]Node{&VarNode{&RefNode{"var"}, nil, true, Pos{1}}},
+			[]Node{&VarNode{&RefNode{"var"}, nil, true, nil, Pos{1, 1}}},
 		},
 		{"silent formal var reference",
 			"$!{var}",
-			[]Node{&VarNode{&RefNode{"var"}, nil, true, Pos{1}}},
+			[]Node{&VarNode{&RefNode{"var"}, nil, true, nil, Pos{1, 1}}},
+		},
+
+		{"formal reference with alternate value",
+			"${var|'default'}",
+			[]Node{&VarNode{&RefNode{"var"}, nil, false, &OpNode{Val: "default", Pos: Pos{1, 7}}, Pos{1, 1}}},
+		},
+		{"silent formal reference with alternate reference",
+			"$!{var.prop|$other}",
+			[]Node{&VarNode{
+				&RefNode{"var"},
+				[]*AccessNode{{"prop", nil, AccessProperty, Pos{1, 8}}},
+				true, &OpNode{Val: &VarNode{&RefNode{"other"}, nil, false, nil, Pos{1, 13}}}, Pos{1, 1},
+			}},
 		},
 
 		{"regular property notation",
 			"$customer1.Address",
 			[]Node{&VarNode{
 				&RefNode{"customer1"},
-				[]*AccessNode{{"Address", nil, AccessProperty, Pos{1}}},
-				false, Pos{1},
+				[]*AccessNode{{"Address", nil, AccessProperty, Pos{1, 12}}},
+				false, nil, Pos{1, 1},
 			}},
 		},
 		{"formal property notation",
 			"${customer1.Address}",
 			[]Node{&VarNode{
 				&RefNode{"customer1"},
-				[]*AccessNode{{"Address", nil, AccessProperty, Pos{1}}},
-				false, Pos{1},
+				[]*AccessNode{{"Address", nil, AccessProperty, Pos{1, 13}}},
+				false, nil, Pos{1, 1},
 			}},
 		},
 
@@ -86,32 +102,32 @@ func TestParse(t *testing.T) {
 			"$customer1.getAddress()",
 			[]Node{&VarNode{
 				&RefNode{"customer1"},
-				[]*AccessNode{{"getAddress", nil, AccessMethod, Pos{1}}},
-				false, Pos{1},
+				[]*AccessNode{{"getAddress", nil, AccessMethod, Pos{1, 12}}},
+				false, nil, Pos{1, 1},
 			}},
 		},
 		{"formal method notation",
 			"${customer1.getAddress()}",
 			[]Node{&VarNode{
 				&RefNode{"customer1"},
-				[]*AccessNode{{"getAddress", nil, AccessMethod, Pos{1}}},
-				false, Pos{1},
+				[]*AccessNode{{"getAddress", nil, AccessMethod, Pos{1, 13}}},
+				false, nil, Pos{1, 1},
 			}},
 		},
 		{"formal method notation with params",
 			`${customer1.setAddress("Somewhere")}`,
 			[]Node{&VarNode{
 				&RefNode{"customer1"},
-				[]*AccessNode{{"setAddress", []*OpNode{{Val: &InterpolatedNode{Items: []Node{TextNode("Somewhere")}}}}, AccessMethod, Pos{1}}},
-				false, Pos{1},
+				[]*AccessNode{{"setAddress", []*OpNode{{Val: &InterpolatedNode{Items: []Node{TextNode("Somewhere")}}}}, AccessMethod, Pos{1, 13}}},
+				false, nil, Pos{1, 1},
 			}},
 		},
 		{"regular method notation with expression in params",
 			`${customer1.setAddress("Somewhere" + 1)}`,
 			[]Node{&VarNode{
 				&RefNode{"customer1"},
-				[]*AccessNode{{"setAddress", []*OpNode{{Op: "+", Left: &OpNode{Val: &InterpolatedNode{Items: []Node{TextNode("Somewhere")}}, Pos: Pos{0}}, Right: &OpNode{Val: int64(1), Pos: Pos{1}}, Pos: Pos{0}}}, AccessMethod, Pos{1}}},
-				false, Pos{1},
+				[]*AccessNode{{"setAddress", []*OpNode{{Op: "+", Left: &OpNode{Val: &InterpolatedNode{Items: []Node{TextNode("Somewhere")}}, Pos: Pos{0, 0}}, Right: &OpNode{Val: int64(1), Pos: Pos{1, 38}}, Pos: Pos{0, 0}}}, AccessMethod, Pos{1, 13}}},
+				false, nil, Pos{1, 1},
 			}},
 		},
 
@@ -124,113 +140,118 @@ func TestParse(t *testing.T) {
 		// 	}},
 		// },
 
+		{"unparsed content",
+			"#[[$var #if( ## ]]#",
+			[]Node{TextNode("$var #if( ## ")},
+		},
+
 		{"set dirctive with var reference",
 			`#set( $monkey = $bill )`,
 			[]Node{&SetNode{
-				&VarNode{&RefNode{"monkey"}, nil, false, Pos{1}},
-				&OpNode{Val: &VarNode{&RefNode{"bill"}, nil, false, Pos{1}}}, Pos{1},
+				&VarNode{&RefNode{"monkey"}, nil, false, nil, Pos{1, 7}},
+				&OpNode{Val: &VarNode{&RefNode{"bill"}, nil, false, nil, Pos{1, 17}}}, Pos{1, 1},
 			}},
 		},
 		{"set directive with string literal",
 			`#set( $monkey.Friend = 'monica' )`,
 			[]Node{&SetNode{
-				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Friend", nil, AccessProperty, Pos{1}}}, false, Pos{1}},
-				&OpNode{Val: "monica", Pos: Pos{1}}, Pos{1},
+				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Friend", nil, AccessProperty, Pos{1, 15}}}, false, nil, Pos{1, 7}},
+				&OpNode{Val: "monica", Pos: Pos{1, 24}}, Pos{1, 1},
 			}},
 		},
 		{"set directive with number literal",
 			`#set( $monkey.Number = 123 )`,
 			[]Node{&SetNode{
-				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Number", nil, AccessProperty, Pos{1}}}, false, Pos{1}},
-				&OpNode{Val: int64(123), Pos: Pos{1}}, Pos{1},
+				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Number", nil, AccessProperty, Pos{1, 15}}}, false, nil, Pos{1, 7}},
+				&OpNode{Val: int64(123), Pos: Pos{1, 24}}, Pos{1, 1},
 			}},
 		},
 		{"set directive with property reference",
 			`#set( $monkey.Blame = $whitehouse.Leak )`,
 			[]Node{&SetNode{
-				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Blame", nil, AccessProperty, Pos{1}}}, false, Pos{1}},
-				&OpNode{Val: &VarNode{&RefNode{"whitehouse"}, []*AccessNode{{"Leak", nil, AccessProperty, Pos{1}}}, false, Pos{1}}}, Pos{1},
+				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Blame", nil, AccessProperty, Pos{1, 15}}}, false, nil, Pos{1, 7}},
+				&OpNode{Val: &VarNode{&RefNode{"whitehouse"}, []*AccessNode{{"Leak", nil, AccessProperty, Pos{1, 35}}}, false, nil, Pos{1, 23}}}, Pos{1, 1},
 			}},
 		},
 		{"set directive with method reference",
 			`#set( $monkey.Plan = $spindoctor.weave($web) )`,
 			[]Node{&SetNode{
-				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Plan", nil, AccessProperty, Pos{1}}}, false, Pos{1}},
+				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Plan", nil, AccessProperty, Pos{1, 15}}}, false, nil, Pos{1, 7}},
 				&OpNode{Val: &VarNode{
 					&RefNode{"spindoctor"},
-					[]*AccessNode{{"weave", []*OpNode{{Val: &VarNode{&RefNode{"web"}, nil, false, Pos{1}}}}, AccessMethod, Pos{1}}}, false, Pos{1}}}, Pos{1},
+					[]*AccessNode{{"weave", []*OpNode{{Val: &VarNode{&RefNode{"web"}, nil, false, nil, Pos{1, 40}}}}, AccessMethod, Pos{1, 34}}}, false, nil, Pos{1, 22}}}, Pos{1, 1},
 			}},
 		},
 		{"set directive with range operator",
 			`#set( $monkey.Numbers = [1..3] )`,
 			[]Node{&SetNode{
-				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Numbers", nil, AccessProperty, Pos{1}}}, false, Pos{1}},
-				&OpNode{Op: "range", Left: &OpNode{Val: int64(1), Pos: Pos{1}}, Right: &OpNode{Val: int64(3), Pos: Pos{1}}, Pos: Pos{1}}, Pos{1},
+				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Numbers", nil, AccessProperty, Pos{1, 15}}}, false, nil, Pos{1, 7}},
+				&OpNode{Op: "range", Left: &OpNode{Val: int64(1), Pos: Pos{1, 26}}, Right: &OpNode{Val: int64(3), Pos: Pos{1, 29}}, Pos: Pos{1, 27}}, Pos{1, 1},
 			}},
 		},
 		{"set directive with object list",
 			`#set( $monkey.Say = ["Not", $my, "fault"] )`,
 			[]Node{&SetNode{
-				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Say", nil, AccessProperty, Pos{1}}}, false, Pos{1}},
+				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Say", nil, AccessProperty, Pos{1, 15}}}, false, nil, Pos{1, 7}},
 				&OpNode{Op: "list", Left: &OpNode{Val: []*OpNode{
 					{Val: &InterpolatedNode{Items: []Node{TextNode("Not")}}},
-					{Val: &VarNode{&RefNode{"my"}, nil, false, Pos{1}}},
+					{Val: &VarNode{&RefNode{"my"}, nil, false, nil, Pos{1, 29}}},
 					{Val: &InterpolatedNode{Items: []Node{TextNode("fault")}}}}},
-				}, Pos{1}}},
+				}, Pos{1, 1}}},
 		},
 		{"set directive with object map",
 			`#set( $monkey.Map = {"banana" : "good", "roast beef" : "bad"})`,
 			[]Node{&SetNode{
-				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Map", nil, AccessProperty, Pos{1}}}, false, Pos{1}},
+				&VarNode{&RefNode{"monkey"}, []*AccessNode{{"Map", nil, AccessProperty, Pos{1, 15}}}, false, nil, Pos{1, 7}},
 				&OpNode{Op: "map", Left: &OpNode{Val: []*OpNode{
 					{Val: &InterpolatedNode{Items: []Node{TextNode("banana")}}},
 					{Val: &InterpolatedNode{Items: []Node{TextNode("good")}}},
 					{Val: &InterpolatedNode{Items: []Node{TextNode("roast beef")}}},
 					{Val: &InterpolatedNode{Items: []Node{TextNode("bad")}}}}},
-				}, Pos{1}}},
+				}, Pos{1, 1}}},
 		},
 		{"set directive with arithmetic RHS",
 			`#set( $value = $foo + 1 )`,
 			[]Node{&SetNode{
-				&VarNode{&RefNode{"value"}, nil, false, Pos{1}},
-				&OpNode{Op: "+", Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, Pos{1}}}, Right: &OpNode{Val: int64(1), Pos: Pos{1}}}, Pos{1},
+				&VarNode{&RefNode{"value"}, nil, false, nil, Pos{1, 7}},
+				&OpNode{Op: "+", Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, nil, Pos{1, 16}}}, Right: &OpNode{Val: int64(1), Pos: Pos{1, 23}}}, Pos{1, 1},
 			}},
 		},
 		{"set directive with complex arithmetic RHS",
 			`#set( $value = $foo * (3 + 1) )`,
 			[]Node{&SetNode{
-				&VarNode{&RefNode{"value"}, nil, false, Pos{1}},
-				&OpNode{Op: "*", Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, Pos{1}}}, Right: &OpNode{Op: "+", Left: &OpNode{Val: int64(3), Pos: Pos{1}}, Right: &OpNode{Val: int64(1), Pos: Pos{1}}}}, Pos{1},
+				&VarNode{&RefNode{"value"}, nil, false, nil, Pos{1, 7}},
+				&OpNode{Op: "*", Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, nil, Pos{1, 16}}}, Right: &OpNode{Op: "+", Left: &OpNode{Val: int64(3), Pos: Pos{1, 24}}, Right: &OpNode{Val: int64(1), Pos: Pos{1, 28}}}}, Pos{1, 1},
 			}},
 		},
 
 		{"condition simple",
 			`#if( !$foo )42#end`,
 			[]Node{&IfNode{
-				&OpNode{Op: "not", Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, Pos{1}}}, Pos: Pos{1}},
+				&OpNode{Op: "not", Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, nil, Pos{1, 7}}}, Pos: Pos{1, 6}},
 				[]Node{TextNode("42")},
-				nil, Pos{1},
+				nil, Pos{1, 1},
 			}},
 		},
 
 		{"condition with else",
 			`#if( $foo == 42 )42#{else}not!#end`,
 			[]Node{&IfNode{
-				&OpNode{Op: "eq", Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, Pos{1}}}, Right: &OpNode{Val: int64(42), Pos: Pos{1}}, Pos: Pos{1}},
+				&OpNode{Op: "eq", Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, nil, Pos{1, 6}}}, Right: &OpNode{Val: int64(42), Pos: Pos{1, 14}}, Pos: Pos{1, 11}},
 				[]Node{TextNode("42")},
-				&IfNode{nil, []Node{TextNode("not!")}, nil, Pos{1}}, Pos{1},
+				&IfNode{nil, []Node{TextNode("not!")}, nil, Pos{1, 20}}, Pos{1, 1},
 			}},
 		},
 
 		{"condition with elseif",
 			`#{if}( $foo == 42 )42#{elseif}($foo > 3)\$foo > 3#{else}#{end}`,
 			[]Node{&IfNode{
-				&OpNode{Op: "eq", Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, Pos{1}}}, Right: &OpNode{Val: int64(42), Pos: Pos{1}}, Pos: Pos{1}},
+				&OpNode{Op: "eq", Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, nil, Pos{1, 8}}}, Right: &OpNode{Val: int64(42), Pos: Pos{1, 16}}, Pos: Pos{1, 13}},
 				[]Node{TextNode("42")},
 				&IfNode{
-					&OpNode{Op: "gt", Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, Pos{1}}}, Right: &OpNode{Val: int64(3), Pos: Pos{1}}, Pos: Pos{1}},
+					&OpNode{Op: "gt", Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, nil, Pos{1, 32}}}, Right: &OpNode{Val: int64(3), Pos: Pos{1, 39}}, Pos: Pos{1, 37}},
 					[]Node{TextNode(`$foo > 3`)},
-					&IfNode{nil, []Node{}, nil, Pos{1}}, Pos{1}}, Pos{1},
+					&IfNode{nil, []Node{}, nil, Pos{1, 50}}, Pos{1, 22}}, Pos{1, 1},
 			}},
 		},
 	}
@@ -239,6 +260,8 @@ func TestParse(t *testing.T) {
 		var (
 			name, template string
 			expected       = []Node{}
+			// column offset of the nodes of each subtest in the template
+			offsets []int
 		)
 
 		for i := range sub {
@@ -246,22 +269,32 @@ func TestParse(t *testing.T) {
 				name += ","
 			}
 			name += sub[i].name
+			offset := utf8.RuneCountInString(template)
 			template += sub[i].template
 			l := len(expected)
-			if l > 0 && len(sub[i].expected) > 0 {
+			nodes := sub[i].expected
+			if l > 0 && len(nodes) > 0 {
 				e1, ok1 := expected[l-1].(TextNode)
-				e2, ok2 := sub[i].expected[0].(TextNode)
+				e2, ok2 := nodes[0].(TextNode)
 				if ok1 && ok2 {
 					expected[l-1] = e1 + e2
-					expected = append(expected, sub[i].expected[1:]...)
-					continue
+					nodes = nodes[1:]
+					offsets = append(offsets, offset)
 				}
 			}
-			expected = append(expected, sub[i].expected...)
+			expected = append(expected, nodes...)
+			for range nodes {
+				offsets = append(offsets, offset)
+			}
 		}
 		t.Run(name, func(t *testing.T) {
 			tmpl, err := Parse(template, "", "")
 			require.NoError(t, err)
+			for i := range tmpl.tree {
+				if i < len(offsets) {
+					shiftCols(reflect.ValueOf(tmpl.tree[i]), -offsets[i])
+				}
+			}
 			assert.EqualValues(t, expected, tmpl.tree, "template AST")
 		})
 	})
@@ -297,9 +330,9 @@ func TestParseConditions(t *testing.T) {
 				}
 			} else if assert.NoError(t, err) {
 				expected := []Node{&IfNode{
-					&OpNode{Op: test.expected, Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, Pos{1}}}, Right: &OpNode{Val: int64(42), Pos: Pos{1}}, Pos: Pos{1}},
+					&OpNode{Op: test.expected, Left: &OpNode{Val: &VarNode{&RefNode{"foo"}, nil, false, nil, Pos{1, 6}}}, Right: &OpNode{Val: int64(42), Pos: Pos{1, 12 + len(test.operator)}}, Pos: Pos{1, 11}},
 					[]Node{TextNode("42")},
-					nil, Pos{1},
+					nil, Pos{1, 1},
 				}}
 
 				assert.EqualValues(t, expected, template.tree, "template AST")
@@ -309,6 +342,33 @@ func TestParseConditions(t *testing.T) {
 
 }
 
+// shiftCols moves columns of all positions found in v by n
+func shiftCols(v reflect.Value, n int) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			shiftCols(v.Elem(), n)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			shiftCols(v.Index(i), n)
+		}
+	case reflect.Struct:
+		if p, ok := v.Interface().(Pos); ok {
+			if p.line != 0 {
+				p.col += n
+				v.Set(reflect.ValueOf(p))
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				shiftCols(v.Field(i), n)
+			}
+		}
+	}
+}
+
 func perm(t *testing.T, tests []Test, n, limit int, used []bool, rec []Test, f func(*testing.T, []Test)) {
 	if n > limit {
 		return
@@ -325,6 +385,66 @@ func perm(t *testing.T, tests []Test, n, limit int, used []bool, rec []Test, f f
 	}
 }
 
+func TestParseErrors(t *testing.T) {
+	type pos struct {
+		line, col  int
+		unexpected string
+	}
+	tests := []struct {
+		name     string
+		template string
+		expected []pos
+	}{
+		{"single",
+			"#set($a = )",
+			[]pos{{1, 11, "')'"}},
+		},
+		{"resync at the next directive",
+			"#set($a = )\ntext $b\n#if($a == ) yes #else no #end\n#foreach($x in ) $x #end\n$ok",
+			[]pos{{1, 11, "')'"}, {3, 11, "')'"}, {4, 16, "')'"}},
+		},
+		{"resync inside block",
+			"#if($a)\n  #set($b = )\n#else\n  #include()\n#end\n$c.",
+			[]pos{{2, 13, "')'"}, {4, 12, "')'"}},
+		},
+		{"unclosed parenthesis",
+			"#if($a\nhello #end\n#set($b = 1)\n#parse(,)",
+			[]pos{{2, 1, "IDENTIFIER"}, {4, 8, "','"}},
+		},
+		{"formal reference",
+			"${a.}\n#set($a = [1,)",
+			[]pos{{1, 5, "'}'"}, {2, 14, "')'"}},
+		},
+		{"unexpected end",
+			"#foreach($i in $list)\n#if($i)\n#end",
+			[]pos{{3, 5, "$end"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parse("test.vm", test.template, nil)
+			var errs SyntaxErrors
+			require.True(t, errors.As(err, &errs), "%v", err)
+			var got []pos
+			for _, e := range errs {
+				assert.Equal(t, "test.vm", e.Name)
+				got = append(got, pos{e.Line, e.Column, e.Unexpected})
+			}
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestParseErrorExpected(t *testing.T) {
+	_, err := ParseWithLoader("#foreach($x in )$x#end", MapLoader{}, "")
+	var errs SyntaxErrors
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 1)
+	assert.Equal(t, "')'", errs[0].Unexpected)
+	assert.Equal(t, []string{"array", "interpolated", "iterable", "map", "'$'", "'['", "'{'"}, errs[0].Expected)
+	assert.EqualError(t, err, "unexpected ')', expected iterable or one of ['$', '[', '{']: line 1, column 16 (#foreach($x in |)$x#end)")
+}
+
 func TestGobble(t *testing.T) {
 	tests := []struct {
 		name     string
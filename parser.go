@@ -20,42 +20,45 @@ type yyXError struct {
 }
 
 const (
-	yyDefault  = 57378
-	yyEofCode  = 57344
-	AND        = 57373
-	BOOLEAN    = 57354
-	BREAK      = 57363
-	CMP        = 57375
-	COMMENT    = 57350
-	DEFINE     = 57365
-	ELSE       = 57358
-	ELSEIF     = 57357
-	END        = 57368
-	EVALUATE   = 57364
-	FLOAT      = 57352
-	FOREACH    = 57359
-	IDENTIFIER = 57346
-	IF         = 57356
-	IN         = 57369
-	INCLUDE    = 57360
-	INDEX      = 57348
-	INT        = 57353
-	MACRO      = 57366
-	MACROCALL  = 57367
-	METHOD     = 57347
-	NOT        = 57374
-	OR         = 57372
-	PARSE      = 57361
-	RANGE      = 57370
-	SET        = 57355
-	STOP       = 57362
-	STRING     = 57351
-	TEXT       = 57349
-	WS         = 57371
-	yyErrCode  = 57345
+	yyDefault          = 57381
+	yyEofCode          = 57344
+	AND                = 57376
+	BLOCKDIRECTIVECALL = 57370
+	BLOCKMACROCALL     = 57368
+	BOOLEAN            = 57354
+	BREAK              = 57363
+	CMP                = 57378
+	COMMENT            = 57350
+	DEFINE             = 57365
+	DIRECTIVECALL      = 57369
+	ELSE               = 57358
+	ELSEIF             = 57357
+	END                = 57371
+	EVALUATE           = 57364
+	FLOAT              = 57352
+	FOREACH            = 57359
+	IDENTIFIER         = 57346
+	IF                 = 57356
+	IN                 = 57372
+	INCLUDE            = 57360
+	INDEX              = 57348
+	INT                = 57353
+	MACRO              = 57366
+	MACROCALL          = 57367
+	METHOD             = 57347
+	NOT                = 57377
+	OR                 = 57375
+	PARSE              = 57361
+	RANGE              = 57373
+	SET                = 57355
+	STOP               = 57362
+	STRING             = 57351
+	TEXT               = 57349
+	WS                 = 57374
+	yyErrCode          = 57345
 
 	yyMaxDepth = 200
-	yyTabOfs   = -91
+	yyTabOfs   = -115
 )
 
 var (
@@ -72,99 +75,103 @@ var (
 	}
 
 	yyXLAT = map[int]int{
-		36:    0,  // '$' (80x)
-		41:    1,  // ')' (74x)
-		44:    2,  // ',' (64x)
-		45:    3,  // '-' (50x)
-		93:    4,  // ']' (49x)
-		125:   5,  // '}' (49x)
-		57349: 6,  // TEXT (48x)
-		57363: 7,  // BREAK (43x)
-		57350: 8,  // COMMENT (43x)
-		57368: 9,  // END (43x)
-		57364: 10, // EVALUATE (43x)
-		57359: 11, // FOREACH (43x)
-		57356: 12, // IF (43x)
-		57360: 13, // INCLUDE (43x)
-		57366: 14, // MACRO (43x)
-		57367: 15, // MACROCALL (43x)
-		57361: 16, // PARSE (43x)
-		57355: 17, // SET (43x)
-		57362: 18, // STOP (43x)
-		34:    19, // '"' (42x)
-		57372: 20, // OR (41x)
-		57393: 21, // interpolated (38x)
-		58:    22, // ':' (35x)
-		57358: 23, // ELSE (34x)
-		57370: 24, // RANGE (34x)
-		40:    25, // '(' (33x)
-		57373: 26, // AND (33x)
-		57357: 27, // ELSEIF (32x)
-		57344: 28, // $end (30x)
-		57351: 29, // STRING (28x)
-		37:    30, // '%' (27x)
-		42:    31, // '*' (27x)
-		43:    32, // '+' (27x)
-		47:    33, // '/' (27x)
-		57354: 34, // BOOLEAN (27x)
-		57352: 35, // FLOAT (27x)
-		57353: 36, // INT (27x)
-		57400: 37, // primary (27x)
-		57375: 38, // CMP (26x)
-		91:    39, // '[' (23x)
-		57390: 40, // expression (23x)
-		57404: 41, // term (23x)
-		123:   42, // '{' (16x)
-		57384: 43, // bool_not (16x)
-		57385: 44, // bool_term (16x)
-		57374: 45, // NOT (16x)
-		57371: 46, // WS (15x)
-		57382: 47, // bool_and (14x)
-		57383: 48, // bool_expr (13x)
-		57381: 49, // array (12x)
-		57398: 50, // map (12x)
-		46:    51, // '.' (11x)
-		57346: 52, // IDENTIFIER (11x)
-		57369: 53, // IN (11x)
-		57386: 54, // directive (8x)
-		57387: 55, // directives (8x)
-		61:    56, // '=' (7x)
-		57403: 57, // setarg (7x)
-		57402: 58, // reference (5x)
-		57379: 59, // arg (4x)
-		33:    60, // '!' (2x)
-		57380: 61, // args (2x)
-		57391: 62, // identifier (2x)
-		57396: 63, // list (2x)
-		57376: 64, // $@1 (1x)
-		57377: 65, // $@2 (1x)
-		57388: 66, // else (1x)
-		57389: 67, // elseifs (1x)
-		57392: 68, // identifiers (1x)
-		57394: 69, // iterable (1x)
-		57395: 70, // kvpairs (1x)
-		57397: 71, // literal (1x)
-		57399: 72, // method (1x)
-		57347: 73, // METHOD (1x)
-		57401: 74, // range (1x)
-		57405: 75, // vtl (1x)
-		57378: 76, // $default (0x)
-		57365: 77, // DEFINE (0x)
-		57345: 78, // error (0x)
-		57348: 79, // INDEX (0x)
+		36:    0,  // '$' (136x)
+		57345: 1,  // error (105x)
+		57349: 2,  // TEXT (97x)
+		57371: 3,  // END (93x)
+		57370: 4,  // BLOCKDIRECTIVECALL (92x)
+		57368: 5,  // BLOCKMACROCALL (92x)
+		57363: 6,  // BREAK (92x)
+		57350: 7,  // COMMENT (92x)
+		57365: 8,  // DEFINE (92x)
+		57369: 9,  // DIRECTIVECALL (92x)
+		57364: 10, // EVALUATE (92x)
+		57359: 11, // FOREACH (92x)
+		57356: 12, // IF (92x)
+		57360: 13, // INCLUDE (92x)
+		57366: 14, // MACRO (92x)
+		57367: 15, // MACROCALL (92x)
+		57361: 16, // PARSE (92x)
+		57355: 17, // SET (92x)
+		57362: 18, // STOP (92x)
+		41:    19, // ')' (83x)
+		44:    20, // ',' (69x)
+		57358: 21, // ELSE (63x)
+		57357: 22, // ELSEIF (59x)
+		57396: 23, // interpolated (57x)
+		45:    24, // '-' (54x)
+		57344: 25, // $end (53x)
+		125:   26, // '}' (53x)
+		93:    27, // ']' (51x)
+		34:    28, // '"' (50x)
+		57375: 29, // OR (43x)
+		40:    30, // '(' (39x)
+		58:    31, // ':' (37x)
+		57373: 32, // RANGE (36x)
+		57376: 33, // AND (35x)
+		57354: 34, // BOOLEAN (33x)
+		57352: 35, // FLOAT (33x)
+		57353: 36, // INT (33x)
+		57403: 37, // primary (33x)
+		57351: 38, // STRING (33x)
+		37:    39, // '%' (29x)
+		42:    40, // '*' (29x)
+		43:    41, // '+' (29x)
+		47:    42, // '/' (29x)
+		91:    43, // '[' (29x)
+		57378: 44, // CMP (28x)
+		57393: 45, // expression (25x)
+		57407: 46, // term (25x)
+		123:   47, // '{' (22x)
+		57389: 48, // directive (21x)
+		57390: 49, // directives (21x)
+		57384: 50, // array (18x)
+		57387: 51, // bool_not (18x)
+		57388: 52, // bool_term (18x)
+		57401: 53, // map (18x)
+		57377: 54, // NOT (18x)
+		57374: 55, // WS (17x)
+		57385: 56, // bool_and (16x)
+		57386: 57, // bool_expr (15x)
+		57372: 58, // IN (13x)
+		46:    59, // '.' (11x)
+		57346: 60, // IDENTIFIER (11x)
+		57406: 61, // setarg (9x)
+		124:   62, // '|' (8x)
+		57382: 63, // arg (8x)
+		61:    64, // '=' (7x)
+		57383: 65, // args (5x)
+		57405: 66, // reference (5x)
+		57394: 67, // identifier (3x)
+		33:    68, // '!' (2x)
+		57391: 69, // else (2x)
+		57392: 70, // elseifs (2x)
+		57399: 71, // list (2x)
+		57379: 72, // $@1 (1x)
+		57380: 73, // $@2 (1x)
+		57395: 74, // identifiers (1x)
+		57397: 75, // iterable (1x)
+		57398: 76, // kvpairs (1x)
+		57400: 77, // literal (1x)
+		57402: 78, // method (1x)
+		57347: 79, // METHOD (1x)
+		57404: 80, // range (1x)
+		57408: 81, // vtl (1x)
+		57381: 82, // $default (0x)
+		57348: 83, // INDEX (0x)
 	}
 
 	yySymNames = []string{
 		"'$'",
-		"')'",
-		"','",
-		"'-'",
-		"']'",
-		"'}'",
+		"error",
 		"TEXT",
+		"END",
+		"BLOCKDIRECTIVECALL",
+		"BLOCKMACROCALL",
 		"BREAK",
 		"COMMENT",
-		"END",
+		"DEFINE",
+		"DIRECTIVECALL",
 		"EVALUATE",
 		"FOREACH",
 		"IF",
@@ -174,55 +181,61 @@ var (
 		"PARSE",
 		"SET",
 		"STOP",
+		"')'",
+		"','",
+		"ELSE",
+		"ELSEIF",
+		"interpolated",
+		"'-'",
+		"$end",
+		"'}'",
+		"']'",
 		"'\"'",
 		"OR",
-		"interpolated",
+		"'('",
 		"':'",
-		"ELSE",
 		"RANGE",
-		"'('",
 		"AND",
-		"ELSEIF",
-		"$end",
+		"BOOLEAN",
+		"FLOAT",
+		"INT",
+		"primary",
 		"STRING",
 		"'%'",
 		"'*'",
 		"'+'",
 		"'/'",
-		"BOOLEAN",
-		"FLOAT",
-		"INT",
-		"primary",
-		"CMP",
 		"'['",
+		"CMP",
 		"expression",
 		"term",
 		"'{'",
+		"directive",
+		"directives",
+		"array",
 		"bool_not",
 		"bool_term",
+		"map",
 		"NOT",
 		"WS",
 		"bool_and",
 		"bool_expr",
-		"array",
-		"map",
+		"IN",
 		"'.'",
 		"IDENTIFIER",
-		"IN",
-		"directive",
-		"directives",
-		"'='",
 		"setarg",
-		"reference",
+		"'|'",
 		"arg",
-		"'!'",
+		"'='",
 		"args",
+		"reference",
 		"identifier",
+		"'!'",
+		"else",
+		"elseifs",
 		"list",
 		"$@1",
 		"$@2",
-		"else",
-		"elseifs",
 		"identifiers",
 		"iterable",
 		"kvpairs",
@@ -232,111 +245,132 @@ var (
 		"range",
 		"vtl",
 		"$default",
-		"DEFINE",
-		"error",
 		"INDEX",
 	}
 
 	yyTokenLiteralStrings = map[int]string{}
 
 	yyReductions = map[int]struct{ xsym, components int }{
-		0:  {0, 1},
-		1:  {75, 1},
-		2:  {55, 0},
-		3:  {55, 2},
-		4:  {55, 2},
-		5:  {55, 2},
-		6:  {55, 2},
-		7:  {54, 7},
-		8:  {54, 7},
-		9:  {54, 8},
-		10: {54, 10},
-		11: {54, 4},
-		12: {54, 4},
-		13: {54, 4},
-		14: {64, 0},
-		15: {54, 7},
-		16: {65, 0},
-		17: {54, 8},
-		18: {54, 3},
-		19: {54, 4},
-		20: {54, 1},
-		21: {54, 1},
-		22: {57, 1},
-		23: {57, 1},
-		24: {57, 1},
-		25: {69, 1},
-		26: {69, 1},
-		27: {69, 1},
-		28: {66, 1},
-		29: {66, 3},
-		30: {67, 0},
-		31: {67, 6},
-		32: {21, 2},
-		33: {21, 4},
-		34: {21, 3},
-		35: {21, 5},
-		36: {72, 3},
-		37: {72, 4},
-		38: {58, 1},
-		39: {58, 3},
-		40: {58, 4},
-		41: {58, 3},
-		42: {49, 2},
-		43: {49, 3},
-		44: {49, 3},
-		45: {74, 3},
-		46: {50, 2},
-		47: {50, 3},
-		48: {70, 3},
-		49: {70, 5},
-		50: {40, 3},
-		51: {40, 3},
-		52: {40, 3},
-		53: {40, 3},
-		54: {40, 3},
-		55: {40, 2},
-		56: {40, 1},
-		57: {41, 1},
-		58: {41, 1},
-		59: {41, 3},
-		60: {48, 1},
-		61: {48, 3},
-		62: {47, 1},
-		63: {47, 3},
-		64: {43, 2},
-		65: {43, 1},
-		66: {44, 1},
-		67: {44, 3},
-		68: {37, 1},
-		69: {37, 3},
-		70: {37, 1},
-		71: {37, 1},
-		72: {37, 1},
-		73: {71, 0},
-		74: {71, 2},
-		75: {71, 2},
-		76: {71, 2},
-		77: {59, 1},
-		78: {59, 1},
-		79: {59, 1},
-		80: {59, 1},
-		81: {61, 1},
-		82: {61, 3},
-		83: {62, 2},
-		84: {62, 4},
-		85: {62, 3},
-		86: {62, 5},
-		87: {68, 1},
-		88: {68, 3},
-		89: {63, 1},
-		90: {63, 3},
+		0:   {0, 1},
+		1:   {81, 1},
+		2:   {49, 0},
+		3:   {49, 2},
+		4:   {49, 2},
+		5:   {49, 2},
+		6:   {49, 2},
+		7:   {49, 2},
+		8:   {48, 7},
+		9:   {48, 7},
+		10:  {48, 8},
+		11:  {48, 10},
+		12:  {48, 4},
+		13:  {48, 4},
+		14:  {48, 4},
+		15:  {48, 6},
+		16:  {72, 0},
+		17:  {48, 7},
+		18:  {73, 0},
+		19:  {48, 8},
+		20:  {48, 3},
+		21:  {48, 4},
+		22:  {48, 5},
+		23:  {48, 6},
+		24:  {48, 3},
+		25:  {48, 4},
+		26:  {48, 5},
+		27:  {48, 6},
+		28:  {48, 2},
+		29:  {48, 5},
+		30:  {48, 4},
+		31:  {48, 6},
+		32:  {48, 2},
+		33:  {48, 2},
+		34:  {48, 2},
+		35:  {48, 4},
+		36:  {48, 4},
+		37:  {48, 2},
+		38:  {48, 4},
+		39:  {48, 2},
+		40:  {48, 4},
+		41:  {48, 1},
+		42:  {48, 1},
+		43:  {61, 1},
+		44:  {61, 1},
+		45:  {61, 1},
+		46:  {75, 1},
+		47:  {75, 1},
+		48:  {75, 1},
+		49:  {69, 1},
+		50:  {69, 3},
+		51:  {70, 0},
+		52:  {70, 6},
+		53:  {70, 4},
+		54:  {23, 2},
+		55:  {23, 4},
+		56:  {23, 3},
+		57:  {23, 5},
+		58:  {23, 6},
+		59:  {23, 7},
+		60:  {78, 3},
+		61:  {78, 4},
+		62:  {66, 1},
+		63:  {66, 3},
+		64:  {66, 4},
+		65:  {66, 3},
+		66:  {50, 2},
+		67:  {50, 3},
+		68:  {50, 3},
+		69:  {80, 3},
+		70:  {53, 2},
+		71:  {53, 3},
+		72:  {76, 3},
+		73:  {76, 5},
+		74:  {45, 3},
+		75:  {45, 3},
+		76:  {45, 3},
+		77:  {45, 3},
+		78:  {45, 3},
+		79:  {45, 2},
+		80:  {45, 1},
+		81:  {46, 1},
+		82:  {46, 1},
+		83:  {46, 3},
+		84:  {57, 1},
+		85:  {57, 3},
+		86:  {56, 1},
+		87:  {56, 3},
+		88:  {51, 2},
+		89:  {51, 1},
+		90:  {52, 1},
+		91:  {52, 3},
+		92:  {37, 1},
+		93:  {37, 3},
+		94:  {37, 1},
+		95:  {37, 1},
+		96:  {37, 1},
+		97:  {77, 0},
+		98:  {77, 2},
+		99:  {77, 2},
+		100: {77, 2},
+		101: {63, 1},
+		102: {63, 1},
+		103: {63, 1},
+		104: {63, 1},
+		105: {65, 1},
+		106: {65, 3},
+		107: {67, 2},
+		108: {67, 4},
+		109: {67, 3},
+		110: {67, 5},
+		111: {74, 1},
+		112: {74, 3},
+		113: {71, 1},
+		114: {71, 3},
 	}
 
 	yyXErrors = map[yyXError]string{
 		yyXError{1, -1}:   "expected $end",
-		yyXError{176, -1}: "expected '$'",
-		yyXError{7, -1}:   "expected '('",
+		yyXError{241, -1}: "expected '$'",
 		yyXError{8, -1}:   "expected '('",
 		yyXError{9, -1}:   "expected '('",
 		yyXError{10, -1}:  "expected '('",
@@ -344,400 +378,546 @@ var (
 		yyXError{12, -1}:  "expected '('",
 		yyXError{13, -1}:  "expected '('",
 		yyXError{14, -1}:  "expected '('",
-		yyXError{70, -1}:  "expected '('",
-		yyXError{169, -1}: "expected '('",
-		yyXError{118, -1}: "expected ')'",
-		yyXError{131, -1}: "expected ')'",
-		yyXError{141, -1}: "expected ')'",
-		yyXError{144, -1}: "expected ')'",
-		yyXError{152, -1}: "expected ')'",
-		yyXError{153, -1}: "expected ')'",
-		yyXError{154, -1}: "expected ')'",
-		yyXError{155, -1}: "expected ')'",
-		yyXError{163, -1}: "expected ')'",
-		yyXError{180, -1}: "expected ')'",
-		yyXError{95, -1}:  "expected ']'",
-		yyXError{127, -1}: "expected '}'",
-		yyXError{129, -1}: "expected '}'",
-		yyXError{166, -1}: "expected END",
-		yyXError{116, -1}: "expected IDENTIFIER",
-		yyXError{123, -1}: "expected IDENTIFIER",
-		yyXError{126, -1}: "expected IDENTIFIER",
-		yyXError{150, -1}: "expected IN",
-		yyXError{140, -1}: "expected STRING",
-		yyXError{114, -1}: "expected arg or one of ['\"', '$', '[', '{', BOOLEAN, FLOAT, INT, STRING]",
-		yyXError{143, -1}: "expected arg or one of ['\"', '$', '[', '{', BOOLEAN, FLOAT, INT, STRING]",
-		yyXError{105, -1}: "expected args or one of ['\"', '$', ')', '[', '{', BOOLEAN, FLOAT, INT, STRING]",
-		yyXError{146, -1}: "expected args or one of ['\"', '$', '[', '{', BOOLEAN, FLOAT, INT, STRING]",
-		yyXError{54, -1}:  "expected bool_and or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{27, -1}:  "expected bool_expr or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{34, -1}:  "expected bool_expr or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{88, -1}:  "expected bool_expr or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{100, -1}: "expected bool_expr or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{170, -1}: "expected bool_expr or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{37, -1}:  "expected bool_not or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{50, -1}:  "expected bool_not or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{165, -1}: "expected directive or else or interpolated or one of ['$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{2, -1}:   "expected directive or interpolated or one of [$end, '$', BREAK, COMMENT, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{173, -1}: "expected directive or interpolated or one of ['$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{157, -1}: "expected directive or interpolated or one of ['$', BREAK, COMMENT, ELSE, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{135, -1}: "expected directive or interpolated or one of ['$', BREAK, COMMENT, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{138, -1}: "expected directive or interpolated or one of ['$', BREAK, COMMENT, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{160, -1}: "expected directive or interpolated or one of ['$', BREAK, COMMENT, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{174, -1}: "expected directive or interpolated or one of ['$', BREAK, COMMENT, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{164, -1}: "expected directives or else or one of ['$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{172, -1}: "expected directives or one of ['$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{156, -1}: "expected directives or one of ['$', BREAK, COMMENT, ELSE, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{134, -1}: "expected directives or one of ['$', BREAK, COMMENT, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{137, -1}: "expected directives or one of ['$', BREAK, COMMENT, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{159, -1}: "expected directives or one of ['$', BREAK, COMMENT, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{168, -1}: "expected directives or one of ['$', BREAK, COMMENT, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{30, -1}:  "expected expression or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, STRING]",
-		yyXError{57, -1}:  "expected expression or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, STRING]",
-		yyXError{58, -1}:  "expected expression or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, STRING]",
-		yyXError{59, -1}:  "expected expression or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, STRING]",
-		yyXError{60, -1}:  "expected expression or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, STRING]",
-		yyXError{61, -1}:  "expected expression or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{15, -1}:  "expected '('",
+		yyXError{16, -1}:  "expected '('",
+		yyXError{17, -1}:  "expected '('",
+		yyXError{18, -1}:  "expected '('",
+		yyXError{19, -1}:  "expected '('",
+		yyXError{76, -1}:  "expected '('",
+		yyXError{227, -1}: "expected '('",
+		yyXError{160, -1}: "expected ')'",
+		yyXError{173, -1}: "expected ')'",
+		yyXError{186, -1}: "expected ')'",
+		yyXError{192, -1}: "expected ')'",
+		yyXError{196, -1}: "expected ')'",
+		yyXError{211, -1}: "expected ')'",
+		yyXError{212, -1}: "expected ')'",
+		yyXError{213, -1}: "expected ')'",
+		yyXError{214, -1}: "expected ')'",
+		yyXError{236, -1}: "expected ')'",
+		yyXError{246, -1}: "expected ')'",
+		yyXError{101, -1}: "expected ']'",
+		yyXError{109, -1}: "expected '}'",
+		yyXError{114, -1}: "expected '}'",
+		yyXError{169, -1}: "expected '}'",
+		yyXError{171, -1}: "expected '}'",
+		yyXError{224, -1}: "expected END",
+		yyXError{239, -1}: "expected END",
+		yyXError{155, -1}: "expected IDENTIFIER",
+		yyXError{165, -1}: "expected IDENTIFIER",
+		yyXError{168, -1}: "expected IDENTIFIER",
+		yyXError{209, -1}: "expected IN",
+		yyXError{128, -1}: "expected arg or one of ['\"', '$', '[', '{', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{190, -1}: "expected arg or one of ['\"', '$', '[', '{', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{194, -1}: "expected arg or one of ['\"', '$', '[', '{', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{116, -1}: "expected args or one of ['\"', '$', ')', '[', '{', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{134, -1}: "expected args or one of ['\"', '$', ')', '[', '{', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{139, -1}: "expected args or one of ['\"', '$', ')', '[', '{', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{150, -1}: "expected args or one of ['\"', '$', ')', '[', '{', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{198, -1}: "expected args or one of ['\"', '$', '[', '{', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{60, -1}:  "expected bool_and or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{33, -1}:  "expected bool_expr or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{40, -1}:  "expected bool_expr or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{94, -1}:  "expected bool_expr or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{106, -1}: "expected bool_expr or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{228, -1}: "expected bool_expr or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{43, -1}:  "expected bool_not or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{56, -1}:  "expected bool_not or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{223, -1}: "expected directive or else or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{238, -1}: "expected directive or else or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{2, -1}:   "expected directive or interpolated or one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{230, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{233, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{204, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{216, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{118, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{130, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{132, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{141, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{146, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{148, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{157, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{177, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{180, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{184, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{188, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{207, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{219, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{234, -1}: "expected directive or interpolated or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{222, -1}: "expected directives or else or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{237, -1}: "expected directives or else or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{229, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{232, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{203, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{215, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{117, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{120, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{127, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{140, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{143, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{145, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{156, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{176, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{179, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{183, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{187, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{206, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{218, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{226, -1}: "expected directives or one of ['$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{36, -1}:  "expected expression or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{63, -1}:  "expected expression or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{64, -1}:  "expected expression or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{65, -1}:  "expected expression or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{66, -1}:  "expected expression or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, STRING]",
 		yyXError{67, -1}:  "expected expression or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, STRING]",
-		yyXError{132, -1}: "expected identifier or '$'",
-		yyXError{117, -1}: "expected identifiers or one of ['$', ')']",
-		yyXError{149, -1}: "expected interpolated or '$'",
-		yyXError{44, -1}:  "expected interpolated or one of ['\"', '$', TEXT, WS]",
-		yyXError{151, -1}: "expected iterable or one of ['$', '[', '{']",
-		yyXError{80, -1}:  "expected kvpairs or one of ['\"', '$', '(', '-', '}', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{73, -1}:  "expected list or one of ['\"', '$', '(', ')', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{79, -1}:  "expected list or range or one of ['\"', '$', '(', '-', '[', ']', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{40, -1}:  "expected literal or one of ['\"', '$', TEXT, WS]",
-		yyXError{26, -1}:  "expected method or one of [IDENTIFIER, METHOD]",
-		yyXError{21, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '=', '[', ']', '}', AND, BREAK, CMP, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
-		yyXError{69, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '=', '[', ']', '}', AND, BREAK, CMP, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
-		yyXError{71, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '=', '[', ']', '}', AND, BREAK, CMP, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
-		yyXError{72, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '=', '[', ']', '}', AND, BREAK, CMP, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
-		yyXError{77, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '=', '[', ']', '}', AND, BREAK, CMP, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
-		yyXError{102, -1}: "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '=', '[', ']', '}', AND, BREAK, CMP, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
-		yyXError{18, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '[', ']', '}', AND, BREAK, CMP, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
-		yyXError{22, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '[', ']', '}', AND, BREAK, CMP, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
-		yyXError{25, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, BREAK, CMP, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
-		yyXError{104, -1}: "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, BREAK, CMP, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
-		yyXError{3, -1}:   "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{4, -1}:   "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{5, -1}:   "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{6, -1}:   "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{15, -1}:  "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{16, -1}:  "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{106, -1}: "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{113, -1}: "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{136, -1}: "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{139, -1}: "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{142, -1}: "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{145, -1}: "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{148, -1}: "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{158, -1}: "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{161, -1}: "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{175, -1}: "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{181, -1}: "expected one of [$end, '$', BREAK, COMMENT, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
-		yyXError{120, -1}: "expected one of ['!', '{', IDENTIFIER]",
-		yyXError{46, -1}:  "expected one of ['\"', '$', TEXT, WS]",
-		yyXError{47, -1}:  "expected one of ['\"', '$', TEXT, WS]",
-		yyXError{48, -1}:  "expected one of ['\"', '$', TEXT, WS]",
-		yyXError{29, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
-		yyXError{31, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
-		yyXError{32, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
-		yyXError{33, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
+		yyXError{73, -1}:  "expected expression or one of ['\"', '$', '(', '-', BOOLEAN, FLOAT, INT, STRING]",
+		yyXError{174, -1}: "expected identifier or '$'",
+		yyXError{182, -1}: "expected identifier or '$'",
+		yyXError{159, -1}: "expected identifiers or one of ['$', ')']",
+		yyXError{202, -1}: "expected interpolated or '$'",
+		yyXError{50, -1}:  "expected interpolated or one of ['\"', '$', TEXT, WS]",
+		yyXError{210, -1}: "expected iterable or one of ['$', '[', '{']",
+		yyXError{86, -1}:  "expected kvpairs or one of ['\"', '$', '(', '-', '}', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{79, -1}:  "expected list or one of ['\"', '$', '(', ')', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{85, -1}:  "expected list or range or one of ['\"', '$', '(', '-', '[', ']', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{46, -1}:  "expected literal or one of ['\"', '$', TEXT, WS]",
+		yyXError{32, -1}:  "expected method or one of [IDENTIFIER, METHOD]",
+		yyXError{26, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '=', '[', ']', '|', '}', AND, BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, CMP, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
+		yyXError{75, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '=', '[', ']', '|', '}', AND, BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, CMP, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
+		yyXError{77, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '=', '[', ']', '|', '}', AND, BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, CMP, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
+		yyXError{78, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '=', '[', ']', '|', '}', AND, BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, CMP, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
+		yyXError{83, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '=', '[', ']', '|', '}', AND, BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, CMP, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
+		yyXError{108, -1}: "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '=', '[', ']', '|', '}', AND, BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, CMP, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
+		yyXError{23, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '[', ']', '}', AND, BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, CMP, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
+		yyXError{27, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '.', '/', ':', '[', ']', '}', AND, BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, CMP, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
+		yyXError{30, -1}:  "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, CMP, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
+		yyXError{110, -1}: "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, CMP, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
+		yyXError{112, -1}: "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, CMP, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
+		yyXError{115, -1}: "expected one of [$end, '\"', '$', '%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, CMP, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, IN, INCLUDE, MACRO, MACROCALL, OR, PARSE, RANGE, SET, STOP, TEXT, WS]",
+		yyXError{3, -1}:   "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{4, -1}:   "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{5, -1}:   "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{6, -1}:   "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{7, -1}:   "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{20, -1}:  "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{21, -1}:  "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{119, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{131, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{133, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{135, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{136, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{138, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{142, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{147, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{149, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{151, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{152, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{154, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{158, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{178, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{181, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{185, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{189, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{191, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{193, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{195, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{197, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{199, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{201, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{205, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{208, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{217, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{220, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{235, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{240, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{242, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{247, -1}: "expected one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, ELSE, ELSEIF, END, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{162, -1}: "expected one of ['!', '{', IDENTIFIER]",
+		yyXError{52, -1}:  "expected one of ['\"', '$', TEXT, WS]",
+		yyXError{53, -1}:  "expected one of ['\"', '$', TEXT, WS]",
+		yyXError{54, -1}:  "expected one of ['\"', '$', TEXT, WS]",
+		yyXError{35, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
+		yyXError{37, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
+		yyXError{38, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
 		yyXError{39, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
-		yyXError{41, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
-		yyXError{42, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
-		yyXError{43, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
 		yyXError{45, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
-		yyXError{53, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
-		yyXError{56, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
+		yyXError{47, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
+		yyXError{48, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
+		yyXError{49, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
+		yyXError{51, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
+		yyXError{59, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
 		yyXError{62, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
-		yyXError{63, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
-		yyXError{64, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
-		yyXError{65, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
-		yyXError{66, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
-		yyXError{68, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, OR, RANGE]",
-		yyXError{35, -1}:  "expected one of [')', ',', ':', ']', '}', AND, OR, RANGE]",
-		yyXError{36, -1}:  "expected one of [')', ',', ':', ']', '}', AND, OR, RANGE]",
-		yyXError{38, -1}:  "expected one of [')', ',', ':', ']', '}', AND, OR, RANGE]",
-		yyXError{49, -1}:  "expected one of [')', ',', ':', ']', '}', AND, OR, RANGE]",
-		yyXError{51, -1}:  "expected one of [')', ',', ':', ']', '}', AND, OR, RANGE]",
+		yyXError{68, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
+		yyXError{69, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
+		yyXError{70, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
+		yyXError{71, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
+		yyXError{72, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, CMP, OR, RANGE]",
+		yyXError{74, -1}:  "expected one of ['%', ')', '*', '+', ',', '-', '/', ':', ']', '}', AND, OR, RANGE]",
+		yyXError{41, -1}:  "expected one of [')', ',', ':', ']', '}', AND, OR, RANGE]",
+		yyXError{42, -1}:  "expected one of [')', ',', ':', ']', '}', AND, OR, RANGE]",
+		yyXError{44, -1}:  "expected one of [')', ',', ':', ']', '}', AND, OR, RANGE]",
 		yyXError{55, -1}:  "expected one of [')', ',', ':', ']', '}', AND, OR, RANGE]",
-		yyXError{74, -1}:  "expected one of [')', ',', ']', '}', OR]",
-		yyXError{75, -1}:  "expected one of [')', ',', ']', '}']",
-		yyXError{76, -1}:  "expected one of [')', ',', ']', '}']",
+		yyXError{57, -1}:  "expected one of [')', ',', ':', ']', '}', AND, OR, RANGE]",
+		yyXError{61, -1}:  "expected one of [')', ',', ':', ']', '}', AND, OR, RANGE]",
+		yyXError{80, -1}:  "expected one of [')', ',', ']', '}', OR]",
+		yyXError{81, -1}:  "expected one of [')', ',', ']', '}']",
 		yyXError{82, -1}:  "expected one of [')', ',', ']', '}']",
-		yyXError{87, -1}:  "expected one of [')', ',', ']', '}']",
+		yyXError{88, -1}:  "expected one of [')', ',', ']', '}']",
 		yyXError{93, -1}:  "expected one of [')', ',', ']', '}']",
-		yyXError{96, -1}:  "expected one of [')', ',', ']', '}']",
-		yyXError{97, -1}:  "expected one of [')', ',', ']', '}']",
-		yyXError{81, -1}:  "expected one of [')', ',', ']']",
-		yyXError{99, -1}:  "expected one of [')', ',', ']']",
-		yyXError{78, -1}:  "expected one of [')', ',']",
-		yyXError{107, -1}: "expected one of [')', ',']",
-		yyXError{108, -1}: "expected one of [')', ',']",
-		yyXError{109, -1}: "expected one of [')', ',']",
-		yyXError{110, -1}: "expected one of [')', ',']",
-		yyXError{111, -1}: "expected one of [')', ',']",
-		yyXError{112, -1}: "expected one of [')', ',']",
-		yyXError{115, -1}: "expected one of [')', ',']",
-		yyXError{119, -1}: "expected one of [')', ',']",
+		yyXError{99, -1}:  "expected one of [')', ',', ']', '}']",
+		yyXError{102, -1}: "expected one of [')', ',', ']', '}']",
+		yyXError{103, -1}: "expected one of [')', ',', ']', '}']",
+		yyXError{87, -1}:  "expected one of [')', ',', ']']",
+		yyXError{105, -1}: "expected one of [')', ',', ']']",
+		yyXError{84, -1}:  "expected one of [')', ',']",
 		yyXError{121, -1}: "expected one of [')', ',']",
 		yyXError{122, -1}: "expected one of [')', ',']",
+		yyXError{123, -1}: "expected one of [')', ',']",
+		yyXError{124, -1}: "expected one of [')', ',']",
 		yyXError{125, -1}: "expected one of [')', ',']",
-		yyXError{128, -1}: "expected one of [')', ',']",
-		yyXError{130, -1}: "expected one of [')', ',']",
-		yyXError{133, -1}: "expected one of [')', ',']",
-		yyXError{147, -1}: "expected one of [')', ',']",
-		yyXError{52, -1}:  "expected one of [')', OR]",
-		yyXError{171, -1}: "expected one of [')', OR]",
-		yyXError{92, -1}:  "expected one of [',', ']', OR, RANGE]",
-		yyXError{94, -1}:  "expected one of [',', ']']",
-		yyXError{83, -1}:  "expected one of [',', '}']",
-		yyXError{86, -1}:  "expected one of [',', '}']",
-		yyXError{91, -1}:  "expected one of [',', '}']",
-		yyXError{178, -1}: "expected one of ['.', '=', '[']",
-		yyXError{24, -1}:  "expected one of ['.', '[', '}']",
-		yyXError{103, -1}: "expected one of ['.', '[', '}']",
-		yyXError{84, -1}:  "expected one of [':', OR]",
-		yyXError{89, -1}:  "expected one of [':', OR]",
-		yyXError{28, -1}:  "expected one of [']', OR]",
-		yyXError{101, -1}: "expected one of [']', OR]",
-		yyXError{124, -1}: "expected one of ['{', IDENTIFIER]",
-		yyXError{167, -1}: "expected one of [ELSE, ELSEIF, END]",
-		yyXError{19, -1}:  "expected reference or IDENTIFIER",
-		yyXError{23, -1}:  "expected reference or IDENTIFIER",
-		yyXError{177, -1}: "expected reference or IDENTIFIER",
-		yyXError{17, -1}:  "expected reference or one of ['!', '{', IDENTIFIER]",
-		yyXError{20, -1}:  "expected reference or one of ['{', IDENTIFIER]",
-		yyXError{85, -1}:  "expected setarg or one of ['\"', '$', '(', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{90, -1}:  "expected setarg or one of ['\"', '$', '(', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{98, -1}:  "expected setarg or one of ['\"', '$', '(', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{162, -1}: "expected setarg or one of ['\"', '$', '(', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{179, -1}: "expected setarg or one of ['\"', '$', '(', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
-		yyXError{0, -1}:   "expected vtl or one of [$end, '$', BREAK, COMMENT, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
+		yyXError{126, -1}: "expected one of [')', ',']",
+		yyXError{129, -1}: "expected one of [')', ',']",
+		yyXError{137, -1}: "expected one of [')', ',']",
+		yyXError{144, -1}: "expected one of [')', ',']",
+		yyXError{153, -1}: "expected one of [')', ',']",
+		yyXError{161, -1}: "expected one of [')', ',']",
+		yyXError{163, -1}: "expected one of [')', ',']",
+		yyXError{164, -1}: "expected one of [')', ',']",
+		yyXError{167, -1}: "expected one of [')', ',']",
+		yyXError{170, -1}: "expected one of [')', ',']",
+		yyXError{172, -1}: "expected one of [')', ',']",
+		yyXError{175, -1}: "expected one of [')', ',']",
+		yyXError{200, -1}: "expected one of [')', ',']",
+		yyXError{58, -1}:  "expected one of [')', OR]",
+		yyXError{231, -1}: "expected one of [')', OR]",
+		yyXError{98, -1}:  "expected one of [',', ']', OR, RANGE]",
+		yyXError{100, -1}: "expected one of [',', ']']",
+		yyXError{89, -1}:  "expected one of [',', '}']",
+		yyXError{92, -1}:  "expected one of [',', '}']",
+		yyXError{97, -1}:  "expected one of [',', '}']",
+		yyXError{244, -1}: "expected one of ['.', '=', '[']",
+		yyXError{29, -1}:  "expected one of ['.', '[', '|', '}']",
+		yyXError{111, -1}: "expected one of ['.', '[', '|', '}']",
+		yyXError{90, -1}:  "expected one of [':', OR]",
+		yyXError{95, -1}:  "expected one of [':', OR]",
+		yyXError{34, -1}:  "expected one of [']', OR]",
+		yyXError{107, -1}: "expected one of [']', OR]",
+		yyXError{166, -1}: "expected one of ['{', IDENTIFIER]",
+		yyXError{225, -1}: "expected one of [ELSE, ELSEIF, END]",
+		yyXError{24, -1}:  "expected reference or IDENTIFIER",
+		yyXError{28, -1}:  "expected reference or IDENTIFIER",
+		yyXError{243, -1}: "expected reference or IDENTIFIER",
+		yyXError{22, -1}:  "expected reference or one of ['!', '{', IDENTIFIER]",
+		yyXError{25, -1}:  "expected reference or one of ['{', IDENTIFIER]",
+		yyXError{31, -1}:  "expected setarg or one of ['\"', '$', '(', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{91, -1}:  "expected setarg or one of ['\"', '$', '(', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{96, -1}:  "expected setarg or one of ['\"', '$', '(', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{104, -1}: "expected setarg or one of ['\"', '$', '(', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{113, -1}: "expected setarg or one of ['\"', '$', '(', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{221, -1}: "expected setarg or one of ['\"', '$', '(', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{245, -1}: "expected setarg or one of ['\"', '$', '(', '-', '[', '{', BOOLEAN, FLOAT, INT, NOT, STRING]",
+		yyXError{0, -1}:   "expected vtl or one of [$end, '$', BLOCKDIRECTIVECALL, BLOCKMACROCALL, BREAK, COMMENT, DEFINE, DIRECTIVECALL, EVALUATE, FOREACH, IF, INCLUDE, MACRO, MACROCALL, PARSE, SET, STOP, TEXT]",
 	}
 
-	yyParseTab = [182][]uint16{
+	yyParseTab = [248][]uint16{
 		// 0
-		{89, 6: 89, 89, 89, 10: 89, 89, 89, 89, 89, 89, 89, 89, 89, 28: 89, 55: 93, 75: 92},
-		{28: 91},
-		{108, 6: 97, 107, 94, 10: 103, 100, 99, 101, 104, 105, 102, 98, 106, 21: 96, 28: 90, 54: 95},
-		{88, 6: 88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 23: 88, 27: 88, 88},
-		{87, 6: 87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 23: 87, 27: 87, 87},
+		{113, 113, 113, 4: 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 25: 113, 49: 117, 81: 116},
+		{25: 115},
+		{137, 121, 122, 4: 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 25: 114, 48: 119},
+		{112, 112, 112, 112, 112, 112, 112, 112, 112, 112, 112, 112, 112, 112, 112, 112, 112, 112, 112, 21: 112, 112, 25: 112},
+		{111, 111, 111, 111, 111, 111, 111, 111, 111, 111, 111, 111, 111, 111, 111, 111, 111, 111, 111, 21: 111, 111, 25: 111},
 		// 5
-		{86, 6: 86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 23: 86, 27: 86, 86},
-		{85, 6: 85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 23: 85, 27: 85, 85},
-		{25: 267},
-		{25: 253},
-		{25: 240},
+		{110, 110, 110, 110, 110, 110, 110, 110, 110, 110, 110, 110, 110, 110, 110, 110, 110, 110, 110, 21: 110, 110, 25: 110},
+		{109, 109, 109, 109, 109, 109, 109, 109, 109, 109, 109, 109, 109, 109, 109, 109, 109, 109, 109, 21: 109, 109, 25: 109},
+		{108, 108, 108, 108, 108, 108, 108, 108, 108, 108, 108, 108, 108, 108, 108, 108, 108, 108, 108, 21: 108, 108, 25: 108},
+		{1: 357, 30: 356},
+		{1: 337, 30: 336},
 		// 10
-		{25: 237},
-		{25: 234},
-		{25: 231},
-		{25: 207},
-		{25: 196},
+		{1: 318, 30: 317},
+		{1: 314, 30: 313},
+		{1: 310, 30: 309},
+		{1: 306, 30: 305},
+		{1: 298, 30: 297},
 		// 15
-		{71, 6: 71, 71, 71, 71, 71, 71, 71, 71, 71, 71, 71, 71, 71, 23: 71, 27: 71, 71},
-		{70, 6: 70, 70, 70, 70, 70, 70, 70, 70, 70, 70, 70, 70, 70, 23: 70, 27: 70, 70},
-		{42: 110, 52: 112, 58: 109, 60: 111},
-		{59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 22: 59, 59, 59, 26: 59, 59, 59, 30: 59, 59, 59, 59, 38: 59, 118, 46: 59, 51: 117, 53: 59},
-		{52: 112, 58: 194},
+		{1: 271, 30: 270},
+		{1: 266, 30: 265},
+		{1: 255, 30: 254},
+		{1: 250, 30: 249},
+		{1: 232, 30: 231},
 		// 20
-		{42: 114, 52: 112, 58: 113},
-		{53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 22: 53, 53, 53, 26: 53, 53, 53, 30: 53, 53, 53, 53, 38: 53, 53, 46: 53, 51: 53, 53: 53, 56: 53},
-		{57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 22: 57, 57, 57, 26: 57, 57, 57, 30: 57, 57, 57, 57, 38: 57, 118, 46: 57, 51: 117, 53: 57},
-		{52: 112, 58: 115},
-		{5: 116, 39: 118, 51: 117},
+		{74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 21: 74, 74, 25: 74},
+		{73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 21: 73, 73, 25: 73},
+		{47: 139, 60: 141, 66: 138, 68: 140},
+		{61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 24: 61, 61, 61, 61, 61, 61, 31: 61, 61, 61, 39: 61, 61, 61, 61, 148, 61, 55: 61, 58: 61, 147},
+		{60: 141, 66: 226},
 		// 25
-		{56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 22: 56, 56, 56, 26: 56, 56, 56, 30: 56, 56, 56, 56, 38: 56, 46: 56, 53: 56},
-		{52: 162, 72: 163, 161},
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 120, 122, 43: 127, 129, 128, 47: 126, 119},
-		{4: 160, 20: 145},
-		{1: 25, 25, 149, 25, 25, 20: 25, 22: 25, 24: 25, 26: 25, 30: 152, 150, 148, 151, 38: 158},
+		{47: 143, 60: 141, 66: 142},
+		{53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 24: 53, 53, 53, 53, 53, 53, 31: 53, 53, 53, 39: 53, 53, 53, 53, 53, 53, 55: 53, 58: 53, 53, 62: 53, 64: 53},
+		{59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 24: 59, 59, 59, 59, 59, 59, 31: 59, 59, 59, 39: 59, 59, 59, 59, 148, 59, 55: 59, 58: 59, 147},
+		{60: 141, 66: 144},
+		{26: 145, 43: 148, 59: 147, 62: 146},
 		// 30
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 147, 122},
-		{1: 35, 35, 35, 35, 35, 20: 35, 22: 35, 24: 35, 26: 35, 30: 35, 35, 35, 35, 38: 35},
-		{1: 34, 34, 34, 34, 34, 20: 34, 22: 34, 24: 34, 26: 34, 30: 34, 34, 34, 34, 38: 34},
-		{1: 33, 33, 33, 33, 33, 20: 33, 22: 33, 24: 33, 26: 33, 30: 33, 33, 33, 33, 38: 33},
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 120, 122, 43: 127, 129, 128, 47: 126, 143},
+		{58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 24: 58, 58, 58, 58, 58, 58, 31: 58, 58, 58, 39: 58, 58, 58, 58, 44: 58, 55: 58, 58: 58},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 43: 200, 45: 150, 152, 201, 50: 196, 157, 159, 197, 158, 56: 156, 195, 61: 224},
+		{60: 192, 78: 193, 191},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 150, 152, 51: 157, 159, 54: 158, 56: 156, 149},
+		{27: 190, 29: 175},
 		// 35
-		{1: 31, 31, 4: 31, 31, 20: 31, 22: 31, 24: 31, 26: 141},
-		{1: 29, 29, 4: 29, 29, 20: 29, 22: 29, 24: 29, 26: 29},
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 120, 122, 43: 140, 129, 128},
-		{1: 26, 26, 4: 26, 26, 20: 26, 22: 26, 24: 26, 26: 26},
-		{1: 23, 23, 23, 23, 23, 20: 23, 22: 23, 24: 23, 26: 23, 30: 23, 23, 23, 23, 38: 23},
+		{19: 25, 25, 24: 179, 26: 25, 25, 29: 25, 31: 25, 25, 25, 39: 182, 180, 178, 181, 44: 188},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 177, 152},
+		{19: 35, 35, 24: 35, 26: 35, 35, 29: 35, 31: 35, 35, 35, 39: 35, 35, 35, 35, 44: 35},
+		{19: 34, 34, 24: 34, 26: 34, 34, 29: 34, 31: 34, 34, 34, 39: 34, 34, 34, 34, 44: 34},
+		{19: 33, 33, 24: 33, 26: 33, 33, 29: 33, 31: 33, 33, 33, 39: 33, 33, 33, 33, 44: 33},
 		// 40
-		{18, 6: 18, 19: 18, 46: 18, 71: 135},
-		{1: 21, 21, 21, 21, 21, 20: 21, 22: 21, 24: 21, 26: 21, 30: 21, 21, 21, 21, 38: 21},
-		{1: 20, 20, 20, 20, 20, 20: 20, 22: 20, 24: 20, 26: 20, 30: 20, 20, 20, 20, 38: 20},
-		{1: 19, 19, 19, 19, 19, 20: 19, 22: 19, 24: 19, 26: 19, 30: 19, 19, 19, 19, 38: 19},
-		{108, 6: 137, 19: 136, 21: 138, 46: 139},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 150, 152, 51: 157, 159, 54: 158, 56: 156, 173},
+		{19: 31, 31, 26: 31, 31, 29: 31, 31: 31, 31, 171},
+		{19: 29, 29, 26: 29, 29, 29: 29, 31: 29, 29, 29},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 150, 152, 51: 170, 159, 54: 158},
+		{19: 26, 26, 26: 26, 26, 29: 26, 31: 26, 26, 26},
 		// 45
-		{1: 22, 22, 22, 22, 22, 20: 22, 22: 22, 24: 22, 26: 22, 30: 22, 22, 22, 22, 38: 22},
-		{17, 6: 17, 19: 17, 46: 17},
-		{16, 6: 16, 19: 16, 46: 16},
-		{15, 6: 15, 19: 15, 46: 15},
-		{1: 27, 27, 4: 27, 27, 20: 27, 22: 27, 24: 27, 26: 27},
+		{19: 23, 23, 24: 23, 26: 23, 23, 29: 23, 31: 23, 23, 23, 39: 23, 23, 23, 23, 44: 23},
+		{18, 2: 18, 28: 18, 55: 18, 77: 165},
+		{19: 21, 21, 24: 21, 26: 21, 21, 29: 21, 31: 21, 21, 21, 39: 21, 21, 21, 21, 44: 21},
+		{19: 20, 20, 24: 20, 26: 20, 20, 29: 20, 31: 20, 20, 20, 39: 20, 20, 20, 20, 44: 20},
+		{19: 19, 19, 24: 19, 26: 19, 19, 29: 19, 31: 19, 19, 19, 39: 19, 19, 19, 19, 44: 19},
 		// 50
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 120, 122, 43: 142, 129, 128},
-		{1: 28, 28, 4: 28, 28, 20: 28, 22: 28, 24: 28, 26: 28},
-		{1: 144, 20: 145},
-		{1: 32, 32, 32, 32, 32, 20: 32, 22: 32, 24: 32, 26: 32, 30: 32, 32, 32, 32, 38: 32},
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 120, 122, 43: 127, 129, 128, 47: 146},
+		{137, 2: 167, 23: 168, 28: 166, 55: 169},
+		{19: 22, 22, 24: 22, 26: 22, 22, 29: 22, 31: 22, 22, 22, 39: 22, 22, 22, 22, 44: 22},
+		{17, 2: 17, 28: 17, 55: 17},
+		{16, 2: 16, 28: 16, 55: 16},
+		{15, 2: 15, 28: 15, 55: 15},
 		// 55
-		{1: 30, 30, 4: 30, 30, 20: 30, 22: 30, 24: 30, 26: 141},
-		{1: 36, 36, 36, 36, 36, 20: 36, 22: 36, 24: 36, 26: 36, 30: 152, 150, 36, 151, 38: 36},
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 157, 122},
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 156, 122},
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 155, 122},
+		{19: 27, 27, 26: 27, 27, 29: 27, 31: 27, 27, 27},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 150, 152, 51: 172, 159, 54: 158},
+		{19: 28, 28, 26: 28, 28, 29: 28, 31: 28, 28, 28},
+		{19: 174, 29: 175},
+		{19: 32, 32, 24: 32, 26: 32, 32, 29: 32, 31: 32, 32, 32, 39: 32, 32, 32, 32, 44: 32},
 		// 60
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 154, 122},
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 153, 122},
-		{1: 37, 37, 37, 37, 37, 20: 37, 22: 37, 24: 37, 26: 37, 30: 37, 37, 37, 37, 38: 37},
-		{1: 38, 38, 38, 38, 38, 20: 38, 22: 38, 24: 38, 26: 38, 30: 38, 38, 38, 38, 38: 38},
-		{1: 39, 39, 39, 39, 39, 20: 39, 22: 39, 24: 39, 26: 39, 30: 39, 39, 39, 39, 38: 39},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 150, 152, 51: 157, 159, 54: 158, 56: 176},
+		{19: 30, 30, 26: 30, 30, 29: 30, 31: 30, 30, 171},
+		{19: 36, 36, 24: 36, 26: 36, 36, 29: 36, 31: 36, 36, 36, 39: 182, 180, 36, 181, 44: 36},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 187, 152},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 186, 152},
 		// 65
-		{1: 40, 40, 40, 40, 40, 20: 40, 22: 40, 24: 40, 26: 40, 30: 152, 150, 40, 151, 38: 40},
-		{1: 41, 41, 41, 41, 41, 20: 41, 22: 41, 24: 41, 26: 41, 30: 152, 150, 41, 151, 38: 41},
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 159, 122},
-		{1: 24, 24, 149, 24, 24, 20: 24, 22: 24, 24: 24, 26: 24, 30: 152, 150, 148, 151},
-		{51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 22: 51, 51, 51, 26: 51, 51, 51, 30: 51, 51, 51, 51, 38: 51, 51, 46: 51, 51: 51, 53: 51, 56: 51},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 185, 152},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 184, 152},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 183, 152},
+		{19: 37, 37, 24: 37, 26: 37, 37, 29: 37, 31: 37, 37, 37, 39: 37, 37, 37, 37, 44: 37},
+		{19: 38, 38, 24: 38, 26: 38, 38, 29: 38, 31: 38, 38, 38, 39: 38, 38, 38, 38, 44: 38},
 		// 70
-		{25: 164},
-		{52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 22: 52, 52, 52, 26: 52, 52, 52, 30: 52, 52, 52, 52, 38: 52, 52, 46: 52, 51: 52, 53: 52, 56: 52},
-		{50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 22: 50, 50, 50, 26: 50, 50, 50, 30: 50, 50, 50, 50, 38: 50, 50, 46: 50, 51: 50, 53: 50, 56: 50},
-		{108, 168, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 39: 170, 120, 122, 171, 127, 129, 128, 47: 126, 165, 166, 167, 57: 172, 63: 169},
-		{1: 69, 69, 4: 69, 69, 20: 145},
+		{19: 39, 39, 24: 39, 26: 39, 39, 29: 39, 31: 39, 39, 39, 39: 39, 39, 39, 39, 44: 39},
+		{19: 40, 40, 24: 40, 26: 40, 40, 29: 40, 31: 40, 40, 40, 39: 182, 180, 40, 181, 44: 40},
+		{19: 41, 41, 24: 41, 26: 41, 41, 29: 41, 31: 41, 41, 41, 39: 182, 180, 41, 181, 44: 41},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 189, 152},
+		{19: 24, 24, 24: 179, 26: 24, 24, 29: 24, 31: 24, 24, 24, 39: 182, 180, 178, 181},
 		// 75
-		{1: 68, 68, 4: 68, 68},
-		{1: 67, 67, 4: 67, 67},
-		{55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 22: 55, 55, 55, 26: 55, 55, 55, 30: 55, 55, 55, 55, 38: 55, 55, 46: 55, 51: 55, 53: 55, 56: 55},
-		{1: 193, 189},
-		{108, 3: 121, 184, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 39: 170, 120, 122, 171, 127, 129, 128, 47: 126, 183, 166, 167, 57: 172, 63: 185, 74: 186},
+		{51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 24: 51, 51, 51, 51, 51, 51, 31: 51, 51, 51, 39: 51, 51, 51, 51, 51, 51, 55: 51, 58: 51, 51, 62: 51, 64: 51},
+		{30: 194},
+		{52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 24: 52, 52, 52, 52, 52, 52, 31: 52, 52, 52, 39: 52, 52, 52, 52, 52, 52, 55: 52, 58: 52, 52, 62: 52, 64: 52},
+		{50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 24: 50, 50, 50, 50, 50, 50, 31: 50, 50, 50, 39: 50, 50, 50, 50, 50, 50, 55: 50, 58: 50, 50, 62: 50, 64: 50},
+		{137, 19: 198, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 43: 200, 45: 150, 152, 201, 50: 196, 157, 159, 197, 158, 56: 156, 195, 61: 202, 71: 199},
 		// 80
-		{108, 3: 121, 5: 173, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 120, 122, 43: 127, 129, 128, 47: 126, 175, 70: 174},
-		{1: 2, 2, 4: 2},
-		{1: 45, 45, 4: 45, 45},
-		{2: 179, 5: 178},
-		{20: 145, 22: 176},
+		{19: 72, 72, 26: 72, 72, 29: 175},
+		{19: 71, 71, 26: 71, 71},
+		{19: 70, 70, 26: 70, 70},
+		{55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 24: 55, 55, 55, 55, 55, 55, 31: 55, 55, 55, 39: 55, 55, 55, 55, 55, 55, 55: 55, 58: 55, 55, 62: 55, 64: 55},
+		{19: 223, 219},
 		// 85
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 39: 170, 120, 122, 171, 127, 129, 128, 47: 126, 165, 166, 167, 57: 177},
-		{2: 43, 5: 43},
-		{1: 44, 44, 4: 44, 44},
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 120, 122, 43: 127, 129, 128, 47: 126, 180},
-		{20: 145, 22: 181},
+		{137, 23: 153, 151, 27: 214, 161, 30: 155, 34: 164, 162, 163, 154, 160, 43: 200, 45: 150, 152, 201, 50: 196, 157, 159, 197, 158, 56: 156, 213, 61: 202, 71: 215, 80: 216},
+		{137, 23: 153, 151, 26: 203, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 150, 152, 51: 157, 159, 54: 158, 56: 156, 205, 76: 204},
+		{19: 2, 2, 27: 2},
+		{19: 45, 45, 26: 45, 45},
+		{20: 209, 26: 208},
 		// 90
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 39: 170, 120, 122, 171, 127, 129, 128, 47: 126, 165, 166, 167, 57: 182},
-		{2: 42, 5: 42},
-		{2: 69, 4: 69, 20: 145, 24: 191},
-		{1: 49, 49, 4: 49, 49},
-		{2: 189, 4: 188},
+		{29: 175, 31: 206},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 43: 200, 45: 150, 152, 201, 50: 196, 157, 159, 197, 158, 56: 156, 195, 61: 207},
+		{20: 43, 26: 43},
+		{19: 44, 44, 26: 44, 44},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 150, 152, 51: 157, 159, 54: 158, 56: 156, 210},
 		// 95
-		{4: 187},
-		{1: 47, 47, 4: 47, 47},
-		{1: 48, 48, 4: 48, 48},
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 39: 170, 120, 122, 171, 127, 129, 128, 47: 126, 165, 166, 167, 57: 190},
-		{1: 1, 1, 4: 1},
+		{29: 175, 31: 211},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 43: 200, 45: 150, 152, 201, 50: 196, 157, 159, 197, 158, 56: 156, 195, 61: 212},
+		{20: 42, 26: 42},
+		{20: 72, 27: 72, 29: 175, 32: 221},
+		{19: 49, 49, 26: 49, 49},
 		// 100
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 120, 122, 43: 127, 129, 128, 47: 126, 192},
-		{4: 46, 20: 145},
-		{54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 22: 54, 54, 54, 26: 54, 54, 54, 30: 54, 54, 54, 54, 38: 54, 54, 46: 54, 51: 54, 53: 54, 56: 54},
-		{5: 195, 39: 118, 51: 117},
-		{58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 22: 58, 58, 58, 26: 58, 58, 58, 30: 58, 58, 58, 58, 38: 58, 46: 58, 53: 58},
+		{20: 219, 27: 218},
+		{27: 217},
+		{19: 47, 47, 26: 47, 47},
+		{19: 48, 48, 26: 48, 48},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 43: 200, 45: 150, 152, 201, 50: 196, 157, 159, 197, 158, 56: 156, 195, 61: 220},
 		// 105
-		{108, 197, 19: 131, 21: 199, 29: 130, 34: 134, 132, 133, 200, 39: 170, 42: 171, 49: 201, 202, 59: 203, 61: 198},
-		{73, 6: 73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 73, 23: 73, 27: 73, 73},
-		{1: 204, 205},
-		{1: 14, 14},
-		{1: 13, 13},
+		{19: 1, 1, 27: 1},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 150, 152, 51: 157, 159, 54: 158, 56: 156, 222},
+		{27: 46, 29: 175},
+		{54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 24: 54, 54, 54, 54, 54, 54, 31: 54, 54, 54, 39: 54, 54, 54, 54, 54, 54, 55: 54, 58: 54, 54, 62: 54, 64: 54},
+		{26: 225},
 		// 110
-		{1: 12, 12},
-		{1: 11, 11},
-		{1: 10, 10},
-		{72, 6: 72, 72, 72, 72, 72, 72, 72, 72, 72, 72, 72, 72, 72, 23: 72, 27: 72, 72},
-		{108, 19: 131, 21: 199, 29: 130, 34: 134, 132, 133, 200, 39: 170, 42: 171, 49: 201, 202, 59: 206},
+		{56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 24: 56, 56, 56, 56, 56, 56, 31: 56, 56, 56, 39: 56, 56, 56, 56, 44: 56, 55: 56, 58: 56},
+		{26: 227, 43: 148, 59: 147, 62: 228},
+		{60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 24: 60, 60, 60, 60, 60, 60, 31: 60, 60, 60, 39: 60, 60, 60, 60, 44: 60, 55: 60, 58: 60},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 43: 200, 45: 150, 152, 201, 50: 196, 157, 159, 197, 158, 56: 156, 195, 61: 229},
+		{26: 230},
 		// 115
-		{1: 9, 9},
-		{52: 208},
-		{211, 77, 62: 212, 64: 209, 68: 210},
-		{1: 228},
-		{1: 75, 223, 65: 222},
+		{57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 24: 57, 57, 57, 57, 57, 57, 31: 57, 57, 57, 39: 57, 57, 57, 57, 44: 57, 55: 57, 58: 57},
+		{137, 19: 235, 23: 237, 28: 161, 34: 164, 162, 163, 238, 160, 43: 200, 47: 201, 50: 239, 53: 240, 63: 241, 65: 236},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 233},
+		{137, 121, 122, 234, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		{75, 75, 75, 75, 75, 75, 75, 75, 75, 75, 75, 75, 75, 75, 75, 75, 75, 75, 75, 21: 75, 75, 25: 75},
 		// 120
-		{42: 214, 52: 213, 60: 215},
-		{1: 4, 4},
-		{1: 8, 8},
-		{52: 220},
-		{42: 217, 52: 216},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 247},
+		{19: 242, 243},
+		{19: 14, 14},
+		{19: 13, 13},
+		{19: 12, 12},
 		// 125
-		{1: 6, 6},
-		{52: 218},
-		{5: 219},
-		{1: 5, 5},
-		{5: 221},
+		{19: 11, 11},
+		{19: 10, 10},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 245},
+		{137, 23: 237, 28: 161, 34: 164, 162, 163, 238, 160, 43: 200, 47: 201, 50: 239, 53: 240, 63: 244},
+		{19: 9, 9},
 		// 130
-		{1: 7, 7},
-		{1: 225},
-		{211, 62: 224},
-		{1: 3, 3},
-		{89, 6: 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 55: 226},
+		{137, 121, 122, 246, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		{88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 88, 21: 88, 88, 25: 88},
+		{137, 121, 122, 248, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		{89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 21: 89, 89, 25: 89},
+		{137, 19: 251, 23: 237, 28: 161, 34: 164, 162, 163, 238, 160, 43: 200, 47: 201, 50: 239, 53: 240, 63: 241, 65: 252},
 		// 135
-		{108, 6: 97, 107, 94, 227, 103, 100, 99, 101, 104, 105, 102, 98, 106, 21: 96, 54: 95},
-		{74, 6: 74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 74, 23: 74, 27: 74, 74},
-		{89, 6: 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 55: 229},
-		{108, 6: 97, 107, 94, 230, 103, 100, 99, 101, 104, 105, 102, 98, 106, 21: 96, 54: 95},
-		{76, 6: 76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 23: 76, 27: 76, 76},
+		{76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 76, 21: 76, 76, 25: 76},
+		{91, 91, 91, 91, 91, 91, 91, 91, 91, 91, 91, 91, 91, 91, 91, 91, 91, 91, 91, 21: 91, 91, 25: 91},
+		{19: 253, 243},
+		{90, 90, 90, 90, 90, 90, 90, 90, 90, 90, 90, 90, 90, 90, 90, 90, 90, 90, 90, 21: 90, 90, 25: 90},
+		{137, 19: 258, 23: 237, 28: 161, 34: 164, 162, 163, 238, 160, 43: 200, 47: 201, 50: 239, 53: 240, 63: 241, 65: 259},
 		// 140
-		{29: 232},
-		{1: 233},
-		{78, 6: 78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 23: 78, 27: 78, 78},
-		{108, 19: 131, 21: 199, 29: 130, 34: 134, 132, 133, 200, 39: 170, 42: 171, 49: 201, 202, 59: 235},
-		{1: 236},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 256},
+		{137, 121, 122, 257, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		{77, 77, 77, 77, 77, 77, 77, 77, 77, 77, 77, 77, 77, 77, 77, 77, 77, 77, 77, 21: 77, 77, 25: 77},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 263},
+		{19: 260, 243},
 		// 145
-		{79, 6: 79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 23: 79, 27: 79, 79},
-		{108, 19: 131, 21: 199, 29: 130, 34: 134, 132, 133, 200, 39: 170, 42: 171, 49: 201, 202, 59: 203, 61: 238},
-		{1: 239, 205},
-		{80, 6: 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 23: 80, 27: 80, 80},
-		{108, 21: 241},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 261},
+		{137, 121, 122, 262, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		{92, 92, 92, 92, 92, 92, 92, 92, 92, 92, 92, 92, 92, 92, 92, 92, 92, 92, 92, 21: 92, 92, 25: 92},
+		{137, 121, 122, 264, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		{93, 93, 93, 93, 93, 93, 93, 93, 93, 93, 93, 93, 93, 93, 93, 93, 93, 93, 93, 21: 93, 93, 25: 93},
 		// 150
-		{53: 242},
-		{108, 21: 244, 39: 170, 42: 171, 49: 245, 246, 69: 243},
-		{1: 247},
-		{1: 66},
-		{1: 65},
+		{137, 19: 267, 23: 237, 28: 161, 34: 164, 162, 163, 238, 160, 43: 200, 47: 201, 50: 239, 53: 240, 63: 241, 65: 268},
+		{78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 78, 21: 78, 78, 25: 78},
+		{95, 95, 95, 95, 95, 95, 95, 95, 95, 95, 95, 95, 95, 95, 95, 95, 95, 95, 95, 21: 95, 95, 25: 95},
+		{19: 269, 243},
+		{94, 94, 94, 94, 94, 94, 94, 94, 94, 94, 94, 94, 94, 94, 94, 94, 94, 94, 94, 21: 94, 94, 25: 94},
 		// 155
-		{1: 64},
-		{89, 6: 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 23: 89, 55: 248},
-		{108, 6: 97, 107, 94, 249, 103, 100, 99, 101, 104, 105, 102, 98, 106, 21: 96, 23: 250, 54: 95},
-		{82, 6: 82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 23: 82, 27: 82, 82},
-		{89, 6: 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 55: 251},
+		{60: 274},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 272},
+		{137, 121, 122, 273, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		{79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 79, 21: 79, 79, 25: 79},
+		{277, 19: 99, 67: 278, 72: 275, 74: 276},
 		// 160
-		{108, 6: 97, 107, 94, 252, 103, 100, 99, 101, 104, 105, 102, 98, 106, 21: 96, 54: 95},
-		{81, 6: 81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 23: 81, 27: 81, 81},
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 39: 170, 120, 122, 171, 127, 129, 128, 47: 126, 165, 166, 167, 57: 254},
-		{1: 255},
-		{89, 6: 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 23: 89, 27: 89, 55: 256},
+		{19: 294},
+		{19: 97, 289, 73: 288},
+		{47: 280, 60: 279, 68: 281},
+		{19: 4, 4},
+		{19: 8, 8},
 		// 165
-		{108, 6: 97, 107, 94, 61, 103, 100, 99, 101, 104, 105, 102, 98, 106, 21: 96, 23: 61, 27: 61, 54: 95, 66: 257, 258},
-		{9: 266},
-		{9: 63, 23: 259, 27: 260},
-		{89, 6: 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 55: 265},
-		{25: 261},
+		{60: 286},
+		{47: 283, 60: 282},
+		{19: 6, 6},
+		{60: 284},
+		{26: 285},
 		// 170
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 40: 120, 122, 43: 127, 129, 128, 47: 126, 262},
-		{1: 263, 20: 145},
-		{89, 6: 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 89, 23: 89, 27: 89, 55: 264},
-		{108, 6: 97, 107, 94, 60, 103, 100, 99, 101, 104, 105, 102, 98, 106, 21: 96, 23: 60, 27: 60, 54: 95},
-		{108, 6: 97, 107, 94, 62, 103, 100, 99, 101, 104, 105, 102, 98, 106, 21: 96, 54: 95},
+		{19: 5, 5},
+		{26: 287},
+		{19: 7, 7},
+		{19: 291},
+		{277, 67: 290},
 		// 175
-		{83, 6: 83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 23: 83, 27: 83, 83},
-		{268},
-		{52: 112, 58: 269},
-		{39: 118, 51: 117, 56: 270},
-		{108, 3: 121, 19: 131, 21: 123, 25: 125, 29: 130, 34: 134, 132, 133, 124, 39: 170, 120, 122, 171, 127, 129, 128, 47: 126, 165, 166, 167, 57: 271},
+		{19: 3, 3},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 292},
+		{137, 121, 122, 293, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		{96, 96, 96, 96, 96, 96, 96, 96, 96, 96, 96, 96, 96, 96, 96, 96, 96, 96, 96, 21: 96, 96, 25: 96},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 295},
 		// 180
-		{1: 272},
-		{84, 6: 84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 23: 84, 27: 84, 84},
+		{137, 121, 122, 296, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		{98, 98, 98, 98, 98, 98, 98, 98, 98, 98, 98, 98, 98, 98, 98, 98, 98, 98, 98, 21: 98, 98, 25: 98},
+		{277, 67: 301},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 299},
+		{137, 121, 122, 300, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		// 185
+		{80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 80, 21: 80, 80, 25: 80},
+		{19: 302},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 303},
+		{137, 121, 122, 304, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		{100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 100, 21: 100, 100, 25: 100},
+		// 190
+		{137, 23: 237, 28: 161, 34: 164, 162, 163, 238, 160, 43: 200, 47: 201, 50: 239, 53: 240, 63: 307},
+		{81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 81, 21: 81, 81, 25: 81},
+		{19: 308},
+		{101, 101, 101, 101, 101, 101, 101, 101, 101, 101, 101, 101, 101, 101, 101, 101, 101, 101, 101, 21: 101, 101, 25: 101},
+		{137, 23: 237, 28: 161, 34: 164, 162, 163, 238, 160, 43: 200, 47: 201, 50: 239, 53: 240, 63: 311},
+		// 195
+		{82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 82, 21: 82, 82, 25: 82},
+		{19: 312},
+		{102, 102, 102, 102, 102, 102, 102, 102, 102, 102, 102, 102, 102, 102, 102, 102, 102, 102, 102, 21: 102, 102, 25: 102},
+		{137, 23: 237, 28: 161, 34: 164, 162, 163, 238, 160, 43: 200, 47: 201, 50: 239, 53: 240, 63: 241, 65: 315},
+		{83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 83, 21: 83, 83, 25: 83},
+		// 200
+		{19: 316, 243},
+		{103, 103, 103, 103, 103, 103, 103, 103, 103, 103, 103, 103, 103, 103, 103, 103, 103, 103, 103, 21: 103, 103, 25: 103},
+		{137, 23: 324},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 21: 113, 49: 319},
+		{137, 121, 122, 320, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 21: 321, 23: 120, 48: 119},
+		// 205
+		{85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 85, 21: 85, 85, 25: 85},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 322},
+		{137, 121, 122, 323, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		{84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 84, 21: 84, 84, 25: 84},
+		{58: 325},
+		// 210
+		{137, 23: 327, 43: 200, 47: 201, 50: 328, 53: 329, 75: 326},
+		{19: 330},
+		{19: 69},
+		{19: 68},
+		{19: 67},
+		// 215
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 21: 113, 49: 331},
+		{137, 121, 122, 332, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 21: 333, 23: 120, 48: 119},
+		{105, 105, 105, 105, 105, 105, 105, 105, 105, 105, 105, 105, 105, 105, 105, 105, 105, 105, 105, 21: 105, 105, 25: 105},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 334},
+		{137, 121, 122, 335, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		// 220
+		{104, 104, 104, 104, 104, 104, 104, 104, 104, 104, 104, 104, 104, 104, 104, 104, 104, 104, 104, 21: 104, 104, 25: 104},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 43: 200, 45: 150, 152, 201, 50: 196, 157, 159, 197, 158, 56: 156, 195, 61: 351},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 21: 113, 113, 49: 338},
+		{137, 121, 122, 64, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 21: 64, 64, 120, 48: 119, 69: 339, 340},
+		{3: 350},
+		// 225
+		{3: 66, 21: 341, 342},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 49: 349},
+		{1: 344, 30: 343},
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 45: 150, 152, 51: 157, 159, 54: 158, 56: 156, 346},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 21: 113, 113, 49: 345},
+		// 230
+		{137, 121, 122, 62, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 21: 62, 62, 120, 48: 119},
+		{19: 347, 29: 175},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 21: 113, 113, 49: 348},
+		{137, 121, 122, 63, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 21: 63, 63, 120, 48: 119},
+		{137, 121, 122, 65, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 23: 120, 48: 119},
+		// 235
+		{86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 86, 21: 86, 86, 25: 86},
+		{19: 352},
+		{113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 113, 21: 113, 113, 49: 353},
+		{137, 121, 122, 64, 134, 132, 136, 118, 129, 133, 128, 125, 124, 126, 130, 131, 127, 123, 135, 21: 64, 64, 120, 48: 119, 69: 354, 340},
+		{3: 355},
+		// 240
+		{106, 106, 106, 106, 106, 106, 106, 106, 106, 106, 106, 106, 106, 106, 106, 106, 106, 106, 106, 21: 106, 106, 25: 106},
+		{358},
+		{87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 87, 21: 87, 87, 25: 87},
+		{60: 141, 66: 359},
+		{43: 148, 59: 147, 64: 360},
+		// 245
+		{137, 23: 153, 151, 28: 161, 30: 155, 34: 164, 162, 163, 154, 160, 43: 200, 45: 150, 152, 201, 50: 196, 157, 159, 197, 158, 56: 156, 195, 61: 361},
+		{19: 362},
+		{107, 107, 107, 107, 107, 107, 107, 107, 107, 107, 107, 107, 107, 107, 107, 107, 107, 107, 107, 21: 107, 107, 25: 107},
 	}
 )
 
@@ -778,7 +958,7 @@ func yylex1(yylex yyLexer, lval *yySymType) (n int) {
 }
 
 func yyParse(yylex yyLexer) int {
-	const yyError = 78
+	const yyError = 1
 
 	yyEx, _ := yylex.(yyLexerEx)
 	var yyn int
@@ -983,6 +1163,10 @@ yynewstate:
 			yyVAL.v = append(yyS[yypt-1].v, yyS[yypt-0].n)
 		}
 	case 6:
+		{
+			yyVAL.v = yyS[yypt-1].v
+		}
+	case 7:
 		{
 			if len(yyS[yypt-1].v) == 0 {
 				yyVAL.v = append(yyS[yypt-1].v, TextNode(yyS[yypt-0].t.literal))
@@ -993,90 +1177,171 @@ yynewstate:
 				yyVAL.v = append(yyS[yypt-1].v, TextNode(yyS[yypt-0].t.literal))
 			}
 		}
-	case 7:
-		{
-			yyVAL.n = &SetNode{Var: yyS[yypt-3].n.(*VarNode), Expr: yyS[yypt-1].n.(*OpNode), Pos: Pos{yyS[yypt-6].t.line}}
-		}
 	case 8:
 		{
-			elseNode, _ := yyS[yypt-1].n.(*IfNode)
-			yyVAL.n = &IfNode{Cond: yyS[yypt-4].n.(*OpNode), Items: yyS[yypt-2].v, Else: elseNode, Pos: Pos{yyS[yypt-6].t.line}}
+			yyS[yypt-3].n.(*VarNode).Pos = yyS[yypt-4].t.pos()
+			yyVAL.n = &SetNode{Var: yyS[yypt-3].n.(*VarNode), Expr: yyS[yypt-1].n.(*OpNode), Pos: yyS[yypt-6].t.pos()}
 		}
 	case 9:
 		{
-			yyVAL.n = &ForeachNode{Var: yyS[yypt-5].n.(*VarNode).RefNode, Iter: yyS[yypt-3].n.(*OpNode), Items: yyS[yypt-1].v, Pos: Pos{yyS[yypt-7].t.line}}
+			elseNode, _ := yyS[yypt-1].n.(*IfNode)
+			yyVAL.n = &IfNode{Cond: yyS[yypt-4].n.(*OpNode), Items: yyS[yypt-2].v, Else: elseNode, Pos: yyS[yypt-6].t.pos()}
 		}
 	case 10:
 		{
-			yyVAL.n = &ForeachNode{Var: yyS[yypt-7].n.(*VarNode).RefNode, Iter: yyS[yypt-5].n.(*OpNode), Items: yyS[yypt-3].v, Else: yyS[yypt-1].v, Pos: Pos{yyS[yypt-9].t.line}}
+			yyVAL.n = &ForeachNode{Var: yyS[yypt-5].n.(*VarNode).RefNode, Iter: yyS[yypt-3].n.(*OpNode), Items: yyS[yypt-1].v, Pos: yyS[yypt-7].t.pos()}
 		}
 	case 11:
 		{
-			yyVAL.n = &IncludeNode{Names: yyS[yypt-1].n.([]*OpNode), Pos: Pos{yyS[yypt-3].t.line}}
+			yyVAL.n = &ForeachNode{Var: yyS[yypt-7].n.(*VarNode).RefNode, Iter: yyS[yypt-5].n.(*OpNode), Items: yyS[yypt-3].v, Else: yyS[yypt-1].v, Pos: yyS[yypt-9].t.pos()}
 		}
 	case 12:
 		{
-			yyVAL.n = &ParseNode{Name: yyS[yypt-1].n.(*OpNode), Pos: Pos{yyS[yypt-3].t.line}}
+			yyVAL.n = &IncludeNode{Names: yyS[yypt-1].n.([]*OpNode), Pos: yyS[yypt-3].t.pos()}
 		}
 	case 13:
 		{
-			yyVAL.n = &EvalNode{Content: yyS[yypt-1].t.literal, Pos: Pos{yyS[yypt-3].t.line}}
+			yyVAL.n = &ParseNode{Name: yyS[yypt-1].n.(*OpNode), Pos: yyS[yypt-3].t.pos()}
 		}
 	case 14:
 		{
-			addMacro(yylex, yyS[yypt-0].t.literal)
+			yyVAL.n = &EvalNode{Content: yyS[yypt-1].n.(*OpNode), Pos: yyS[yypt-3].t.pos()}
 		}
 	case 15:
 		{
-			yyVAL.n = &MacroNode{Name: yyS[yypt-4].t.literal, Assign: nil, Items: yyS[yypt-1].v, Pos: Pos{yyS[yypt-6].t.line}}
+			yyVAL.n = &DefineNode{Var: yyS[yypt-3].n.(*VarNode).RefNode, Items: yyS[yypt-1].v, Pos: yyS[yypt-5].t.pos()}
 		}
 	case 16:
 		{
-			addMacro(yylex, yyS[yypt-1].t.literal)
+			addMacro(yylex, yyS[yypt-0].t)
 		}
 	case 17:
 		{
-			yyVAL.n = &MacroNode{Name: yyS[yypt-5].t.literal, Assign: yyS[yypt-4].n.([]*RefNode), Items: yyS[yypt-1].v, Pos: Pos{yyS[yypt-7].t.line}}
+			yyVAL.n = &MacroNode{Name: yyS[yypt-4].t.literal, Assign: nil, Items: yyS[yypt-1].v, Pos: yyS[yypt-6].t.pos(), file: yylex.(*Lexer).name}
 		}
 	case 18:
 		{
-			yyVAL.n = &MacroCall{Name: yyS[yypt-2].t.literal, Vals: nil, Pos: Pos{yyS[yypt-2].t.line}}
+			addMacro(yylex, yyS[yypt-1].t)
 		}
 	case 19:
 		{
-			yyVAL.n = &MacroCall{Name: yyS[yypt-3].t.literal, Vals: yyS[yypt-1].n.([]*OpNode), Pos: Pos{yyS[yypt-3].t.line}}
+			yyVAL.n = &MacroNode{Name: yyS[yypt-5].t.literal, Assign: yyS[yypt-4].n.([]*RefNode), Items: yyS[yypt-1].v, Pos: yyS[yypt-7].t.pos(), file: yylex.(*Lexer).name}
 		}
 	case 20:
 		{
-			yyVAL.n = &StopNode{}
+			yyVAL.n = &MacroCall{Name: yyS[yypt-2].t.literal, Vals: nil, Pos: yyS[yypt-2].t.pos()}
 		}
 	case 21:
 		{
-			yyVAL.n = &BreakNode{}
+			yyVAL.n = &MacroCall{Name: yyS[yypt-3].t.literal, Vals: yyS[yypt-1].n.([]*OpNode), Pos: yyS[yypt-3].t.pos()}
+		}
+	case 22:
+		{
+			yyVAL.n = &MacroCall{Name: yyS[yypt-4].t.literal, Vals: nil, Body: yyS[yypt-1].v, Pos: yyS[yypt-4].t.pos()}
+		}
+	case 23:
+		{
+			yyVAL.n = &MacroCall{Name: yyS[yypt-5].t.literal, Vals: yyS[yypt-3].n.([]*OpNode), Body: yyS[yypt-1].v, Pos: yyS[yypt-5].t.pos()}
+		}
+	case 24:
+		{
+			yyVAL.n = directiveCall(yylex, yyS[yypt-2].t, nil, nil)
 		}
 	case 25:
 		{
-			yyVAL.n = &OpNode{Val: yyS[yypt-0].n}
+			yyVAL.n = directiveCall(yylex, yyS[yypt-3].t, yyS[yypt-1].n.([]*OpNode), nil)
+		}
+	case 26:
+		{
+			yyVAL.n = directiveCall(yylex, yyS[yypt-4].t, nil, yyS[yypt-1].v)
+		}
+	case 27:
+		{
+			yyVAL.n = directiveCall(yylex, yyS[yypt-5].t, yyS[yypt-3].n.([]*OpNode), yyS[yypt-1].v)
+		}
+	case 28:
+		{
+			yyVAL.n = nil
 		}
 	case 29:
+		{
+			yyVAL.n = nil
+		}
+	case 30:
+		{
+			yyVAL.n = nil
+		}
+	case 31:
+		{
+			yyVAL.n = nil
+		}
+	case 32:
+		{
+			yyVAL.n = nil
+		}
+	case 33:
+		{
+			yyVAL.n = nil
+		}
+	case 34:
+		{
+			yyVAL.n = nil
+		}
+	case 35:
+		{
+			yyVAL.n = nil
+		}
+	case 36:
+		{
+			yyVAL.n = nil
+		}
+	case 37:
+		{
+			yyVAL.n = nil
+		}
+	case 38:
+		{
+			yyVAL.n = nil
+		}
+	case 39:
+		{
+			yyVAL.n = nil
+		}
+	case 40:
+		{
+			yyVAL.n = nil
+		}
+	case 41:
+		{
+			yyVAL.n = &StopNode{}
+		}
+	case 42:
+		{
+			yyVAL.n = &BreakNode{}
+		}
+	case 46:
+		{
+			yyVAL.n = &OpNode{Val: yyS[yypt-0].n}
+		}
+	case 50:
 		{
 			ifNode, _ := yyS[yypt-2].n.(*IfNode)
 			if ifNode == nil {
-				yyVAL.n = &IfNode{Items: yyS[yypt-0].v, Pos: Pos{yyS[yypt-1].t.line}}
+				yyVAL.n = &IfNode{Items: yyS[yypt-0].v, Pos: yyS[yypt-1].t.pos()}
 			} else {
 				for ifNode.Else != nil {
 					ifNode = ifNode.Else
 				}
-				ifNode.Else = &IfNode{Items: yyS[yypt-0].v, Pos: Pos{yyS[yypt-1].t.line}}
+				ifNode.Else = &IfNode{Items: yyS[yypt-0].v, Pos: yyS[yypt-1].t.pos()}
 			}
 		}
-	case 30:
+	case 51:
 		{
 			yyVAL.n = nil
 		}
-	case 31:
+	case 52:
 		{
-			elseifNode := &IfNode{Cond: yyS[yypt-2].n.(*OpNode), Items: yyS[yypt-0].v, Pos: Pos{yyS[yypt-4].t.line}}
+			elseifNode := &IfNode{Cond: yyS[yypt-2].n.(*OpNode), Items: yyS[yypt-0].v, Pos: yyS[yypt-4].t.pos()}
 			ifNode, _ := yyS[yypt-5].n.(*IfNode)
 			if ifNode == nil {
 				yyVAL.n = elseifNode
@@ -1084,234 +1349,255 @@ yynewstate:
 				ifNode.Else = elseifNode
 			}
 		}
-	case 32:
+	case 53:
 		{
+			yyVAL.n = yyS[yypt-3].n
+		}
+	case 54:
+		{
+			yyS[yypt-0].n.(*VarNode).Pos = yyS[yypt-1].t.pos()
 			yyVAL.n = yyS[yypt-0].n
 		}
-	case 33:
+	case 55:
 		{
+			yyS[yypt-1].n.(*VarNode).Pos = yyS[yypt-3].t.pos()
 			yyVAL.n = yyS[yypt-1].n
 		}
-	case 34:
+	case 56:
 		{
 			yyS[yypt-0].n.(*VarNode).Silent = true
+			yyS[yypt-0].n.(*VarNode).Pos = yyS[yypt-2].t.pos()
 			yyVAL.n = yyS[yypt-0].n
 		}
-	case 35:
+	case 57:
 		{
 			yyS[yypt-1].n.(*VarNode).Silent = true
+			yyS[yypt-1].n.(*VarNode).Pos = yyS[yypt-4].t.pos()
 			yyVAL.n = yyS[yypt-1].n
 		}
-	case 36:
+	case 58:
 		{
-			yyVAL.n = &AccessNode{Name: yyS[yypt-2].t.literal, Kind: AccessMethod, Pos: Pos{yyS[yypt-2].t.line}}
+			yyS[yypt-3].n.(*VarNode).Alt = yyS[yypt-1].n.(*OpNode)
+			yyS[yypt-3].n.(*VarNode).Pos = yyS[yypt-5].t.pos()
+			yyVAL.n = yyS[yypt-3].n
 		}
-	case 37:
+	case 59:
 		{
-			yyVAL.n = &AccessNode{Name: yyS[yypt-3].t.literal, Kind: AccessMethod, Args: yyS[yypt-1].n.([]*OpNode), Pos: Pos{yyS[yypt-3].t.line}}
+			yyS[yypt-3].n.(*VarNode).Silent = true
+			yyS[yypt-3].n.(*VarNode).Alt = yyS[yypt-1].n.(*OpNode)
+			yyS[yypt-3].n.(*VarNode).Pos = yyS[yypt-6].t.pos()
+			yyVAL.n = yyS[yypt-3].n
 		}
-	case 38:
+	case 60:
 		{
-			yyVAL.n = &VarNode{RefNode: &RefNode{Name: yyS[yypt-0].t.literal}, Pos: Pos{yyS[yypt-0].t.line}}
+			yyVAL.n = &AccessNode{Name: yyS[yypt-2].t.literal, Kind: AccessMethod, Pos: yyS[yypt-2].t.pos()}
 		}
-	case 39:
+	case 61:
+		{
+			yyVAL.n = &AccessNode{Name: yyS[yypt-3].t.literal, Kind: AccessMethod, Args: yyS[yypt-1].n.([]*OpNode), Pos: yyS[yypt-3].t.pos()}
+		}
+	case 62:
+		{
+			yyVAL.n = &VarNode{RefNode: &RefNode{Name: yyS[yypt-0].t.literal}, Pos: yyS[yypt-0].t.pos()}
+		}
+	case 63:
 		{
 			v := yyS[yypt-2].n.(*VarNode)
-			v.Items = append(v.Items, &AccessNode{Name: yyS[yypt-0].t.literal, Kind: AccessProperty, Pos: Pos{yyS[yypt-0].t.line}})
+			v.Items = append(v.Items, &AccessNode{Name: yyS[yypt-0].t.literal, Kind: AccessProperty, Pos: yyS[yypt-0].t.pos()})
 			yyVAL.n = yyS[yypt-2].n
 		}
-	case 40:
+	case 64:
 		{
 			v := yyS[yypt-3].n.(*VarNode)
 			v.Items = append(v.Items, &AccessNode{Kind: AccessIndex, Args: []*OpNode{yyS[yypt-1].n.(*OpNode)}})
 			yyVAL.n = yyS[yypt-3].n
 		}
-	case 41:
+	case 65:
 		{
 			v := yyS[yypt-2].n.(*VarNode)
 			v.Items = append(v.Items, yyS[yypt-0].n.(*AccessNode))
 			yyVAL.n = yyS[yypt-2].n
 		}
-	case 42:
+	case 66:
 		{
 			yyVAL.n = &OpNode{Op: "list", Left: &OpNode{Val: []*OpNode{}}}
 		}
-	case 43:
+	case 67:
 		{
 			yyVAL.n = &OpNode{Op: "list", Left: &OpNode{Val: yyS[yypt-1].n.([]*OpNode)}}
 		}
-	case 44:
+	case 68:
 		{
 			yyVAL.n = yyS[yypt-1].n
 		}
-	case 45:
+	case 69:
 		{
-			yyVAL.n = &OpNode{Op: "range", Left: yyS[yypt-2].n.(*OpNode), Right: yyS[yypt-0].n.(*OpNode), Pos: Pos{yyS[yypt-1].t.line}}
+			yyVAL.n = &OpNode{Op: "range", Left: yyS[yypt-2].n.(*OpNode), Right: yyS[yypt-0].n.(*OpNode), Pos: yyS[yypt-1].t.pos()}
 		}
-	case 46:
+	case 70:
 		{
 			yyVAL.n = &OpNode{Op: "map", Left: &OpNode{Val: []*OpNode{}}}
 		}
-	case 47:
+	case 71:
 		{
 			yyVAL.n = &OpNode{Op: "map", Left: yyS[yypt-1].n.(*OpNode)}
 		}
-	case 48:
+	case 72:
 		{
 			yyVAL.n = &OpNode{Val: []*OpNode{yyS[yypt-2].n.(*OpNode), yyS[yypt-0].n.(*OpNode)}}
 		}
-	case 49:
+	case 73:
 		{
 			v := yyS[yypt-4].n.(*OpNode).Val.([]*OpNode)
 			v = append(v, yyS[yypt-2].n.(*OpNode), yyS[yypt-0].n.(*OpNode))
 			yyS[yypt-4].n.(*OpNode).Val = v
 			yyVAL.n = yyS[yypt-4].n
 		}
-	case 50:
+	case 74:
 		{
 			yyVAL.n = &OpNode{Op: "+", Left: yyS[yypt-2].n.(*OpNode), Right: yyS[yypt-0].n.(*OpNode)}
 		}
-	case 51:
+	case 75:
 		{
 			yyVAL.n = &OpNode{Op: "-", Left: yyS[yypt-2].n.(*OpNode), Right: yyS[yypt-0].n.(*OpNode)}
 		}
-	case 52:
+	case 76:
 		{
 			yyVAL.n = &OpNode{Op: "*", Left: yyS[yypt-2].n.(*OpNode), Right: yyS[yypt-0].n.(*OpNode)}
 		}
-	case 53:
+	case 77:
 		{
 			yyVAL.n = &OpNode{Op: "/", Left: yyS[yypt-2].n.(*OpNode), Right: yyS[yypt-0].n.(*OpNode)}
 		}
-	case 54:
+	case 78:
 		{
 			yyVAL.n = &OpNode{Op: "%", Left: yyS[yypt-2].n.(*OpNode), Right: yyS[yypt-0].n.(*OpNode)}
 		}
-	case 55:
+	case 79:
 		{
 			yyVAL.n = &OpNode{Op: "negate", Left: yyS[yypt-0].n.(*OpNode)}
 		}
-	case 57:
+	case 81:
 		{
 			yyVAL.n = &OpNode{Val: yyS[yypt-0].n}
 		}
-	case 59:
+	case 83:
 		{
 			yyVAL.n = yyS[yypt-1].n
 		}
-	case 61:
+	case 85:
 		{
-			yyVAL.n = &OpNode{Op: yyS[yypt-1].t.literal, Left: yyS[yypt-2].n.(*OpNode), Right: yyS[yypt-0].n.(*OpNode), Pos: Pos{yyS[yypt-1].t.line}}
+			yyVAL.n = &OpNode{Op: yyS[yypt-1].t.literal, Left: yyS[yypt-2].n.(*OpNode), Right: yyS[yypt-0].n.(*OpNode), Pos: yyS[yypt-1].t.pos()}
 		}
-	case 63:
+	case 87:
 		{
-			yyVAL.n = &OpNode{Op: yyS[yypt-1].t.literal, Left: yyS[yypt-2].n.(*OpNode), Right: yyS[yypt-0].n.(*OpNode), Pos: Pos{yyS[yypt-1].t.line}}
+			yyVAL.n = &OpNode{Op: yyS[yypt-1].t.literal, Left: yyS[yypt-2].n.(*OpNode), Right: yyS[yypt-0].n.(*OpNode), Pos: yyS[yypt-1].t.pos()}
 		}
-	case 64:
+	case 88:
 		{
-			yyVAL.n = &OpNode{Op: "not", Left: yyS[yypt-0].n.(*OpNode), Pos: Pos{yyS[yypt-1].t.line}}
+			yyVAL.n = &OpNode{Op: "not", Left: yyS[yypt-0].n.(*OpNode), Pos: yyS[yypt-1].t.pos()}
 		}
-	case 67:
+	case 91:
 		{
-			yyVAL.n = &OpNode{Op: yyS[yypt-1].t.literal, Left: yyS[yypt-2].n.(*OpNode), Right: yyS[yypt-0].n.(*OpNode), Pos: Pos{yyS[yypt-1].t.line}}
+			yyVAL.n = &OpNode{Op: yyS[yypt-1].t.literal, Left: yyS[yypt-2].n.(*OpNode), Right: yyS[yypt-0].n.(*OpNode), Pos: yyS[yypt-1].t.pos()}
 		}
-	case 68:
+	case 92:
 		{
-			yyVAL.n = &OpNode{Val: yyS[yypt-0].t.literal, Pos: Pos{yyS[yypt-0].t.line}}
+			yyVAL.n = &OpNode{Val: yyS[yypt-0].t.literal, Pos: yyS[yypt-0].t.pos()}
 		}
-	case 69:
+	case 93:
 		{
 			yyVAL.n = &OpNode{Val: yyS[yypt-1].n}
 		}
-	case 70:
+	case 94:
 		{
 			f, err := strconv.ParseFloat(yyS[yypt-0].t.literal, 64)
 			if err != nil {
 				yylex.(*Lexer).Error(err.Error())
 				return yyError
 			}
-			yyVAL.n = &OpNode{Val: f, Pos: Pos{yyS[yypt-0].t.line}}
+			yyVAL.n = &OpNode{Val: f, Pos: yyS[yypt-0].t.pos()}
 		}
-	case 71:
+	case 95:
 		{
 			i, err := strconv.ParseInt(yyS[yypt-0].t.literal, 10, 64)
 			if err != nil {
 				yylex.(*Lexer).Error(err.Error())
 				return yyError
 			}
-			yyVAL.n = &OpNode{Val: i, Pos: Pos{yyS[yypt-0].t.line}}
+			yyVAL.n = &OpNode{Val: i, Pos: yyS[yypt-0].t.pos()}
 		}
-	case 72:
+	case 96:
 		{
 			var b bool
 			if yyS[yypt-0].t.literal == "true" {
 				b = true
 			}
-			yyVAL.n = &OpNode{Val: b, Pos: Pos{yyS[yypt-0].t.line}}
+			yyVAL.n = &OpNode{Val: b, Pos: yyS[yypt-0].t.pos()}
 		}
-	case 73:
+	case 97:
 		{
 			yyVAL.n = &InterpolatedNode{}
 		}
-	case 74:
+	case 98:
 		{
 			v := yyS[yypt-1].n.(*InterpolatedNode)
 			v.Items = append(v.Items, TextNode(yyS[yypt-0].t.literal))
 			yyVAL.n = v
 		}
-	case 75:
+	case 99:
 		{
 			v := yyS[yypt-1].n.(*InterpolatedNode)
 			v.Items = append(v.Items, yyS[yypt-0].n.(*VarNode))
 			yyVAL.n = v
 		}
-	case 76:
+	case 100:
 		{
 			v := yyS[yypt-1].n.(*InterpolatedNode)
 			v.Items = append(v.Items, TextNode(yyS[yypt-0].t.literal))
 			yyVAL.n = v
 		}
-	case 77:
+	case 101:
 		{
 			yyVAL.n = &OpNode{Val: yyS[yypt-0].n.(*VarNode)}
 		}
-	case 81:
+	case 105:
 		{
 			yyVAL.n = []*OpNode{yyS[yypt-0].n.(*OpNode)}
 		}
-	case 82:
+	case 106:
 		{
 			yyVAL.n = append(yyS[yypt-2].n.([]*OpNode), yyS[yypt-0].n.(*OpNode))
 		}
-	case 83:
+	case 107:
 		{
-			yyVAL.n = &VarNode{RefNode: &RefNode{Name: yyS[yypt-0].t.literal}, Pos: Pos{yyS[yypt-0].t.line}}
+			yyVAL.n = &VarNode{RefNode: &RefNode{Name: yyS[yypt-0].t.literal}, Pos: yyS[yypt-1].t.pos()}
 		}
-	case 84:
+	case 108:
 		{
-			yyVAL.n = &VarNode{RefNode: &RefNode{Name: yyS[yypt-1].t.literal}, Pos: Pos{yyS[yypt-1].t.line}}
+			yyVAL.n = &VarNode{RefNode: &RefNode{Name: yyS[yypt-1].t.literal}, Pos: yyS[yypt-3].t.pos()}
 		}
-	case 85:
+	case 109:
 		{
-			yyVAL.n = &VarNode{RefNode: &RefNode{Name: yyS[yypt-0].t.literal}, Pos: Pos{yyS[yypt-0].t.line}}
+			yyVAL.n = &VarNode{RefNode: &RefNode{Name: yyS[yypt-0].t.literal}, Pos: yyS[yypt-2].t.pos()}
 		}
-	case 86:
+	case 110:
 		{
-			yyVAL.n = &VarNode{RefNode: &RefNode{Name: yyS[yypt-1].t.literal}, Pos: Pos{yyS[yypt-1].t.line}}
+			yyVAL.n = &VarNode{RefNode: &RefNode{Name: yyS[yypt-1].t.literal}, Pos: yyS[yypt-4].t.pos()}
 		}
-	case 87:
+	case 111:
 		{
 			yyVAL.n = []*RefNode{yyS[yypt-0].n.(*VarNode).RefNode}
 		}
-	case 88:
+	case 112:
 		{
 			yyVAL.n = append(yyS[yypt-2].n.([]*RefNode), yyS[yypt-0].n.(*VarNode).RefNode)
 		}
-	case 89:
+	case 113:
 		{
 			yyVAL.n = []*OpNode{yyS[yypt-0].n.(*OpNode)}
 		}
-	case 90:
+	case 114:
 		{
 			n := yyS[yypt-2].n.([]*OpNode)
 			yyVAL.n = append(n, yyS[yypt-0].n.(*OpNode))
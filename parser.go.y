@@ -17,8 +17,8 @@ import "strconv"
 %type   <n>             directive reference method interpolated expression term setarg else elseifs range iterable map kvpairs array list primary identifiers identifier args arg literal
 %type   <n>             bool_expr bool_and bool_not bool_term
 %token  <t>             IDENTIFIER METHOD INDEX TEXT COMMENT STRING FLOAT INT BOOLEAN error
-%token  <t>             SET IF ELSEIF ELSE FOREACH INCLUDE PARSE STOP BREAK EVALUATE DEFINE MACRO MACROCALL END
-%token  <t>             IN RANGE WS
+%token  <t>             SET IF ELSEIF ELSE FOREACH INCLUDE PARSE STOP BREAK EVALUATE DEFINE MACRO MACROCALL BLOCKMACROCALL DIRECTIVECALL BLOCKDIRECTIVECALL END
+%token  <t>             IN RANGE WS '$'
 %left   <t>             OR
 %left   <t>             AND NOT
 %left   <t>             CMP
@@ -38,6 +38,8 @@ directives:     /*empty */
                 { $$ = append($1, $2) }
         |       directives interpolated
                 { $$ = append($1, $2) }
+        |       directives error
+                { $$ = $1 }
         |       directives TEXT
                 {
                     if len($1) == 0 {
@@ -52,32 +54,74 @@ directives:     /*empty */
                 ;
 
 directive:      SET '(' '$' reference '=' setarg ')'
-                { $$ = &SetNode{Var: $4.(*VarNode), Expr: $6.(*OpNode), Pos: Pos{$1.line}} }
+                { $4.(*VarNode).Pos = $3.pos(); $$ = &SetNode{Var: $4.(*VarNode), Expr: $6.(*OpNode), Pos: $1.pos()} }
         |       IF '(' setarg ')' directives else END
                 {
                     elseNode, _ := $6.(*IfNode)
-                    $$ = &IfNode{Cond: $3.(*OpNode), Items: $5, Else: elseNode, Pos: Pos{$1.line} }
+                    $$ = &IfNode{Cond: $3.(*OpNode), Items: $5, Else: elseNode, Pos: $1.pos() }
                 }
         |       FOREACH '(' interpolated IN iterable ')' directives END
-                { $$ = &ForeachNode{Var: $3.(*VarNode).RefNode, Iter: $5.(*OpNode), Items: $7, Pos: Pos{$1.line}} }
+                { $$ = &ForeachNode{Var: $3.(*VarNode).RefNode, Iter: $5.(*OpNode), Items: $7, Pos: $1.pos()} }
         |       FOREACH '(' interpolated IN iterable ')' directives ELSE directives END
-                { $$ = &ForeachNode{Var: $3.(*VarNode).RefNode, Iter: $5.(*OpNode), Items: $7, Else: $9, Pos: Pos{$1.line}} }
+                { $$ = &ForeachNode{Var: $3.(*VarNode).RefNode, Iter: $5.(*OpNode), Items: $7, Else: $9, Pos: $1.pos()} }
         |       INCLUDE '(' args ')'
-                { $$ = &IncludeNode{Names: $3.([]*OpNode), Pos: Pos{$1.line}} }
+                { $$ = &IncludeNode{Names: $3.([]*OpNode), Pos: $1.pos()} }
         |       PARSE '(' arg ')'
-                { $$ = &ParseNode{Name: $3.(*OpNode), Pos: Pos{$1.line}} }
-        |       EVALUATE '(' STRING ')'
-                { $$ = &EvalNode{Content: $3.literal, Pos: Pos{$1.line}} }
+                { $$ = &ParseNode{Name: $3.(*OpNode), Pos: $1.pos()} }
+        |       EVALUATE '(' arg ')'
+                { $$ = &EvalNode{Content: $3.(*OpNode), Pos: $1.pos()} }
+        |       DEFINE '(' identifier ')' directives END
+                { $$ = &DefineNode{Var: $3.(*VarNode).RefNode, Items: $5, Pos: $1.pos()} }
         |       MACRO '(' IDENTIFIER
-                {addMacro(yylex, $3.literal) } ')' directives END
-                { $$ = &MacroNode{Name: $3.literal, Assign: nil, Items: $6, Pos: Pos{$1.line}} }
+                {addMacro(yylex, $3) } ')' directives END
+                { $$ = &MacroNode{Name: $3.literal, Assign: nil, Items: $6, Pos: $1.pos(), file: yylex.(*Lexer).name} }
         |       MACRO '(' IDENTIFIER identifiers
-                {addMacro(yylex, $3.literal) } ')' directives END
-                { $$ = &MacroNode{Name: $3.literal, Assign: $4.([]*RefNode), Items: $7, Pos: Pos{$1.line}} }
+                {addMacro(yylex, $3) } ')' directives END
+                { $$ = &MacroNode{Name: $3.literal, Assign: $4.([]*RefNode), Items: $7, Pos: $1.pos(), file: yylex.(*Lexer).name} }
         |       MACROCALL '(' ')'
-                { $$ = &MacroCall{ Name: $1.literal, Vals: nil, Pos: Pos{$1.line} } }
+                { $$ = &MacroCall{ Name: $1.literal, Vals: nil, Pos: $1.pos() } }
         |       MACROCALL '(' args ')'
-                { $$ = &MacroCall{ Name: $1.literal, Vals: $3.([]*OpNode), Pos: Pos{$1.line} } }
+                { $$ = &MacroCall{ Name: $1.literal, Vals: $3.([]*OpNode), Pos: $1.pos() } }
+        |       BLOCKMACROCALL '(' ')' directives END
+                { $$ = &MacroCall{ Name: $1.literal, Vals: nil, Body: $4, Pos: $1.pos() } }
+        |       BLOCKMACROCALL '(' args ')' directives END
+                { $$ = &MacroCall{ Name: $1.literal, Vals: $3.([]*OpNode), Body: $5, Pos: $1.pos() } }
+        |       DIRECTIVECALL '(' ')'
+                { $$ = directiveCall(yylex, $1, nil, nil) }
+        |       DIRECTIVECALL '(' args ')'
+                { $$ = directiveCall(yylex, $1, $3.([]*OpNode), nil) }
+        |       BLOCKDIRECTIVECALL '(' ')' directives END
+                { $$ = directiveCall(yylex, $1, nil, $4) }
+        |       BLOCKDIRECTIVECALL '(' args ')' directives END
+                { $$ = directiveCall(yylex, $1, $3.([]*OpNode), $5) }
+        /* error recovery, skip to the end of the block or to the end of
+           the directive */
+        |       SET error
+                { $$ = nil }
+        |       IF error directives else END
+                { $$ = nil }
+        |       FOREACH error directives END
+                { $$ = nil }
+        |       FOREACH error directives ELSE directives END
+                { $$ = nil }
+        |       INCLUDE error
+                { $$ = nil }
+        |       PARSE error
+                { $$ = nil }
+        |       EVALUATE error
+                { $$ = nil }
+        |       DEFINE error directives END
+                { $$ = nil }
+        |       MACRO error directives END
+                { $$ = nil }
+        |       MACROCALL error
+                { $$ = nil }
+        |       BLOCKMACROCALL error directives END
+                { $$ = nil }
+        |       DIRECTIVECALL error
+                { $$ = nil }
+        |       BLOCKDIRECTIVECALL error directives END
+                { $$ = nil }
         |       STOP
                 { $$ = &StopNode{} }
         |       BREAK
@@ -100,12 +144,12 @@ else:           elseifs
                 {
                     ifNode, _ := $1.(*IfNode)
                     if ifNode == nil {
-                        $$ = &IfNode{Items: $3, Pos: Pos{$2.line}}
+                        $$ = &IfNode{Items: $3, Pos: $2.pos()}
                     } else {
                         for ifNode.Else != nil {
                             ifNode = ifNode.Else
                         }
-                        ifNode.Else = &IfNode{Items: $3, Pos: Pos{$2.line}}
+                        ifNode.Else = &IfNode{Items: $3, Pos: $2.pos()}
                     }
                 }
                 ;
@@ -114,7 +158,7 @@ elseifs:        /* nothing */
                 { $$ = nil }
         |       elseifs ELSEIF '(' bool_expr ')' directives
                 {
-                    elseifNode := &IfNode{Cond: $4.(*OpNode), Items: $6, Pos: Pos{$2.line}}
+                    elseifNode := &IfNode{Cond: $4.(*OpNode), Items: $6, Pos: $2.pos()}
                     ifNode, _ := $1.(*IfNode)
                     if ifNode == nil {
                         $$ = elseifNode
@@ -122,30 +166,36 @@ elseifs:        /* nothing */
                         ifNode.Else = elseifNode
                     }
                 }
+        |       elseifs ELSEIF error directives
+                { $$ = $1 }
                 ;
 
 interpolated:   '$' reference
-                { $$ = $2 }
+                { $2.(*VarNode).Pos = $1.pos(); $$ = $2 }
         |       '$' '{' reference '}'
-                { $$ = $3 }
+                { $3.(*VarNode).Pos = $1.pos(); $$ = $3 }
         |       '$' '!' reference
-                { $3.(*VarNode).Silent = true; $$ = $3 }
+                { $3.(*VarNode).Silent = true; $3.(*VarNode).Pos = $1.pos(); $$ = $3 }
         |       '$' '!' '{' reference '}'
-                { $4.(*VarNode).Silent = true; $$ = $4 }
+                { $4.(*VarNode).Silent = true; $4.(*VarNode).Pos = $1.pos(); $$ = $4 }
+        |       '$' '{' reference '|' setarg '}'
+                { $3.(*VarNode).Alt = $5.(*OpNode); $3.(*VarNode).Pos = $1.pos(); $$ = $3 }
+        |       '$' '!' '{' reference '|' setarg '}'
+                { $4.(*VarNode).Silent = true; $4.(*VarNode).Alt = $6.(*OpNode); $4.(*VarNode).Pos = $1.pos(); $$ = $4 }
                 ;
 
 method:         METHOD '(' ')'
-                { $$ = &AccessNode{Name: $1.literal, Kind: AccessMethod, Pos: Pos{$1.line}} }
+                { $$ = &AccessNode{Name: $1.literal, Kind: AccessMethod, Pos: $1.pos()} }
         |       METHOD '(' list ')'
-                { $$ = &AccessNode{Name: $1.literal, Kind: AccessMethod, Args: $3.([]*OpNode), Pos: Pos{$1.line}} }
+                { $$ = &AccessNode{Name: $1.literal, Kind: AccessMethod, Args: $3.([]*OpNode), Pos: $1.pos()} }
                 ;
 
 reference:      IDENTIFIER
-                { $$ = &VarNode{RefNode: &RefNode{Name: $1.literal}, Pos: Pos{$1.line}} }
+                { $$ = &VarNode{RefNode: &RefNode{Name: $1.literal}, Pos: $1.pos()} }
         |       reference '.' IDENTIFIER
                 {
                     v := $1.(*VarNode)
-                    v.Items = append(v.Items, &AccessNode{Name: $3.literal, Kind: AccessProperty, Pos: Pos{$3.line}})
+                    v.Items = append(v.Items, &AccessNode{Name: $3.literal, Kind: AccessProperty, Pos: $3.pos()})
                     $$ = $1
                 }
         |       reference '[' bool_expr ']'
@@ -171,7 +221,7 @@ array:          '[' ']'
                 ;
 
 range:          bool_expr RANGE bool_expr
-                { $$ = &OpNode{Op: "range", Left: $1.(*OpNode), Right: $3.(*OpNode), Pos: Pos{$2.line}} }
+                { $$ = &OpNode{Op: "range", Left: $1.(*OpNode), Right: $3.(*OpNode), Pos: $2.pos()} }
                 ;
 
 map:            '{' '}'
@@ -215,26 +265,26 @@ term:           interpolated
 
 bool_expr:      bool_and
         |       bool_expr OR bool_and
-                { $$ = &OpNode{Op: $2.literal, Left: $1.(*OpNode), Right: $3.(*OpNode), Pos: Pos{$2.line}} }
+                { $$ = &OpNode{Op: $2.literal, Left: $1.(*OpNode), Right: $3.(*OpNode), Pos: $2.pos()} }
                 ;
 
 bool_and:       bool_not
         |       bool_and AND bool_not
-                { $$ = &OpNode{Op: $2.literal, Left: $1.(*OpNode), Right: $3.(*OpNode), Pos: Pos{$2.line}} }
+                { $$ = &OpNode{Op: $2.literal, Left: $1.(*OpNode), Right: $3.(*OpNode), Pos: $2.pos()} }
                 ;
 
 bool_not:       NOT bool_not
-                { $$ = &OpNode{Op: "not", Left: $2.(*OpNode), Pos: Pos{$1.line}} }
+                { $$ = &OpNode{Op: "not", Left: $2.(*OpNode), Pos: $1.pos()} }
         |       bool_term
                 ;
 
 bool_term:      expression
         |       expression CMP expression
-                { $$ = &OpNode{Op: $2.literal, Left: $1.(*OpNode), Right: $3.(*OpNode), Pos: Pos{$2.line}} }
+                { $$ = &OpNode{Op: $2.literal, Left: $1.(*OpNode), Right: $3.(*OpNode), Pos: $2.pos()} }
         ;
 
 primary:        STRING
-                { $$ = &OpNode{Val: $1.literal, Pos: Pos{$1.line}} }
+                { $$ = &OpNode{Val: $1.literal, Pos: $1.pos()} }
         |       '"' literal '"'
                 { $$ = &OpNode{Val: $2} }
         |       FLOAT
@@ -244,7 +294,7 @@ primary:        STRING
                         yylex.(*Lexer).Error(err.Error())
                         return yyError
                     }
-                    $$ = &OpNode{Val: f, Pos: Pos{$1.line}}
+                    $$ = &OpNode{Val: f, Pos: $1.pos()}
                 }
         |       INT
                 {
@@ -253,7 +303,7 @@ primary:        STRING
                         yylex.(*Lexer).Error(err.Error())
                         return yyError
                     }
-                    $$ = &OpNode{Val: i, Pos: Pos{$1.line}}
+                    $$ = &OpNode{Val: i, Pos: $1.pos()}
                 }
         |       BOOLEAN
                 {
@@ -261,7 +311,7 @@ primary:        STRING
                     if $1.literal == "true" {
                         b = true
                     }
-                    $$ = &OpNode{Val: b, Pos: Pos{$1.line}}
+                    $$ = &OpNode{Val: b, Pos: $1.pos()}
                 }
                 ;
 
@@ -301,13 +351,13 @@ args:           arg
                 ;
 
 identifier:     '$' IDENTIFIER
-                { $$ = &VarNode{RefNode: &RefNode{Name: $2.literal}, Pos: Pos{$2.line}} }
+                { $$ = &VarNode{RefNode: &RefNode{Name: $2.literal}, Pos: $1.pos()} }
         |       '$' '{' IDENTIFIER '}'
-                { $$ = &VarNode{RefNode: &RefNode{Name: $3.literal}, Pos: Pos{$3.line}} }
+                { $$ = &VarNode{RefNode: &RefNode{Name: $3.literal}, Pos: $1.pos()} }
         |       '$' '!' IDENTIFIER
-                { $$ = &VarNode{RefNode: &RefNode{Name: $3.literal}, Pos: Pos{$3.line}} }
+                { $$ = &VarNode{RefNode: &RefNode{Name: $3.literal}, Pos: $1.pos()} }
         |       '$' '!' '{' IDENTIFIER '}'
-                { $$ = &VarNode{RefNode: &RefNode{Name: $4.literal}, Pos: Pos{$4.line}} }
+                { $$ = &VarNode{RefNode: &RefNode{Name: $4.literal}, Pos: $1.pos()} }
                 ;
 
 identifiers:    identifier
@@ -0,0 +1,183 @@
+package govtl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SecurityPolicy restricts methods and fields templates can use. Rules are
+// set per type or per package and list Go names of methods and fields, rule
+// without names applies to all of them. Deny rules take precedence, and if
+// there is any allow rule, everything not allowed is denied. Builtin types
+// (strings, lists, maps, ranges, $foreach) are subject only to deny rules.
+// Printing a value which implements fmt.Stringer is a call of its String,
+// printing a struct reads all its exported fields
+type SecurityPolicy struct {
+	allow, deny   rules
+	denySetters   bool
+	denyFieldSet  bool
+	allowlistMode bool
+}
+
+type rules struct {
+	types map[reflect.Type]map[string]bool
+	pkgs  map[string]map[string]bool
+}
+
+// NotAllowedError is returned when template uses method or sets field
+// forbidden by the SecurityPolicy
+type NotAllowedError struct {
+	Type  reflect.Type
+	Name  string
+	Field bool
+}
+
+func (e *NotAllowedError) Error() string {
+	if e.Field {
+		return fmt.Sprintf("setting field not allowed: %s.%s", e.Type, e.Name)
+	}
+	return fmt.Sprintf("method not allowed: %s.%s", e.Type, e.Name)
+}
+
+// builtinTypes are types provided by the engine itself, they are not
+// affected by the allowlist and the setters ban
+var builtinTypes = map[reflect.Type]bool{}
+
+func init() {
+	for _, v := range []interface{}{Str(""), &Map{}, &MapEntry{}, &KeyView{}, &ValView{}, &EntryView{},
+		&Slice{}, &CollectionIterator{}, &MapIterator{}, &Range{}, &foreach{}, &block{}} {
+		builtinTypes[baseType(reflect.TypeOf(v))] = true
+	}
+}
+
+func NewSecurityPolicy() *SecurityPolicy {
+	return &SecurityPolicy{
+		allow: rules{make(map[reflect.Type]map[string]bool), make(map[string]map[string]bool)},
+		deny:  rules{make(map[reflect.Type]map[string]bool), make(map[string]map[string]bool)},
+	}
+}
+
+// AllowType allows methods of the type of v, pointer and value types are
+// treated the same
+func (p *SecurityPolicy) AllowType(v interface{}, methods ...string) *SecurityPolicy {
+	p.allowlistMode = true
+	p.allow.addType(baseType(reflect.TypeOf(v)), methods)
+	return p
+}
+
+// DenyType denies methods of the type of v, pointer and value types are
+// treated the same
+func (p *SecurityPolicy) DenyType(v interface{}, methods ...string) *SecurityPolicy {
+	p.deny.addType(baseType(reflect.TypeOf(v)), methods)
+	return p
+}
+
+// AllowPackage allows methods of all types of the package with import path
+// pkg, path ending with /... matches all subpackages too
+func (p *SecurityPolicy) AllowPackage(pkg string, methods ...string) *SecurityPolicy {
+	p.allowlistMode = true
+	p.allow.addPackage(pkg, methods)
+	return p
+}
+
+// DenyPackage denies methods of all types of the package with import path
+// pkg, path ending with /... matches all subpackages too
+func (p *SecurityPolicy) DenyPackage(pkg string, methods ...string) *SecurityPolicy {
+	p.deny.addPackage(pkg, methods)
+	return p
+}
+
+// DenySetters denies all methods with Set prefix of non-builtin types,
+// including ones called by #set
+func (p *SecurityPolicy) DenySetters() *SecurityPolicy {
+	p.denySetters = true
+	return p
+}
+
+// DenyFieldSet denies #set on struct fields
+func (p *SecurityPolicy) DenyFieldSet() *SecurityPolicy {
+	p.denyFieldSet = true
+	return p
+}
+
+// WithSecurityPolicy sets policy for methods and fields used by template,
+// nil removes all restrictions
+func (t *Template) WithSecurityPolicy(p *SecurityPolicy) *Template {
+	t.policy = p
+	return t
+}
+
+// addNames adds names to the set of the rule, nil set means all names
+func addNames(set map[string]bool, exists bool, names []string) map[string]bool {
+	if len(names) == 0 || exists && set == nil {
+		return nil
+	}
+	if set == nil {
+		set = make(map[string]bool)
+	}
+	for _, n := range names {
+		set[n] = true
+	}
+	return set
+}
+
+func (r rules) addType(t reflect.Type, names []string) {
+	set, ok := r.types[t]
+	r.types[t] = addNames(set, ok, names)
+}
+
+func (r rules) addPackage(pkg string, names []string) {
+	set, ok := r.pkgs[pkg]
+	r.pkgs[pkg] = addNames(set, ok, names)
+}
+
+func baseType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func (r rules) match(t reflect.Type, name string) bool {
+	if set, ok := r.types[t]; ok && (set == nil || set[name]) {
+		return true
+	}
+	pkg := t.PkgPath()
+	for p, set := range r.pkgs {
+		if !(p == pkg || strings.HasSuffix(p, "/...") && (pkg == p[:len(p)-4] || strings.HasPrefix(pkg, p[:len(p)-3]))) {
+			continue
+		}
+		if set == nil || set[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// check returns an error if method or field name of v is not allowed
+func (p *SecurityPolicy) check(v reflect.Value, name string) error {
+	if p == nil {
+		return nil
+	}
+	t := baseType(v.Type())
+	own := builtinTypes[t]
+	switch {
+	case p.deny.match(t, name),
+		p.denySetters && !own && strings.HasPrefix(name, "Set"),
+		p.allowlistMode && !own && !p.allow.match(t, name):
+		return &NotAllowedError{Type: v.Type(), Name: name}
+	}
+	return nil
+}
+
+// checkFieldSet returns an error if setting field name of v is not allowed
+func (p *SecurityPolicy) checkFieldSet(v reflect.Value, name string) error {
+	if p == nil {
+		return nil
+	}
+	if p.denyFieldSet {
+		return &NotAllowedError{Type: v.Type(), Name: name, Field: true}
+	}
+	return p.check(v, name)
+}
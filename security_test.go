@@ -0,0 +1,110 @@
+package govtl
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type account struct {
+	Name    string
+	Balance int
+}
+
+func (a *account) GetOwner() string    { return "owner of " + a.Name }
+func (a *account) Withdraw(n int) int  { a.Balance -= n; return a.Balance }
+func (a *account) SetName(name string) { a.Name = name }
+
+type point struct {
+	X, Y int
+}
+
+type token struct{}
+
+func (*token) String() string { return "token" }
+
+type flag bool
+
+func (flag) String() string { return "flag" }
+
+func TestSecurityPolicy(t *testing.T) {
+	now := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		policy    *SecurityPolicy
+		tmpl      string
+		expect    string
+		expectErr string
+	}{
+		{"no policy",
+			nil, `$a.name $a.owner $a.withdraw(10) $now.year()`, "acme owner of acme 90 2020", ""},
+		{"deny method",
+			NewSecurityPolicy().DenyType(&account{}, "Withdraw"), `$a.owner $a.withdraw(10)`, "owner of acme ", "method not allowed: *govtl.account.Withdraw"},
+		{"deny method by value type",
+			NewSecurityPolicy().DenyType(account{}, "GetOwner"), `$a.name $a.owner`, "acme ", "method not allowed: *govtl.account.GetOwner"},
+		{"deny type",
+			NewSecurityPolicy().DenyType(account{}), `$a.name`, "", "method not allowed: *govtl.account.Name"},
+		{"deny package",
+			NewSecurityPolicy().DenyPackage("time"), `$a.name $now.year()`, "acme ", "method not allowed: time.Time.Year"},
+		{"deny package methods",
+			NewSecurityPolicy().DenyPackage("time", "Format"), `$now.year() $now.format('2006')`, "2020 ", "method not allowed: time.Time.Format"},
+		{"allow method",
+			NewSecurityPolicy().AllowType(&account{}, "GetOwner"), `$a.owner $a.name`, "owner of acme ", "method not allowed: *govtl.account.Name"},
+		{"allow methods in several calls",
+			NewSecurityPolicy().AllowType(&account{}, "GetOwner").AllowType(&account{}, "Name"), `$a.owner $a.name $now.year()`, "owner of acme acme ", "method not allowed: time.Time.Year"},
+		{"allow package tree",
+			NewSecurityPolicy().AllowPackage("github.com/iron-s/..."), `$a.withdraw(1) $now.year()`, "99 ", "method not allowed: time.Time.Year"},
+		{"deny wins",
+			NewSecurityPolicy().AllowPackage("time").DenyType(now, "Year"), `$now.month() $now.year()`, "1 ", "method not allowed: time.Time.Year"},
+		{"own types are allowed",
+			NewSecurityPolicy().AllowType(now), `#set($l = [1, 2])$l.add(3)$l.set(0, 5)$l $l.size()`, "true1[5, 2, 3] 3", ""},
+		{"deny setters",
+			NewSecurityPolicy().DenySetters(), `$a.setName('x')`, "", "method not allowed: *govtl.account.SetName"},
+		{"deny setters in #set",
+			NewSecurityPolicy().DenySetters(), `#set($a.name = 'x')$a.name`, "", "method not allowed: *govtl.account.SetName"},
+		{"own setters",
+			NewSecurityPolicy().DenySetters(), `#set($m = {})#set($m.k = 1)#set($l = [0])$l.set(0, 2)$m $l`, "0{k=1} [2]", ""},
+		{"set field",
+			nil, `#set($p.x = 3)$p.x`, "3", ""},
+		{"deny field set",
+			NewSecurityPolicy().DenyFieldSet(), `$p.y#set($p.x = 3)`, "2", "setting field not allowed: *govtl.point.X"},
+		{"deny field read",
+			NewSecurityPolicy().DenyType(point{}, "X"), `$p.y#set($p.x = 3)`, "2", "method not allowed: *govtl.point.X"},
+		{"deny field print",
+			NewSecurityPolicy().DenyType(point{}, "X"), `$a.name $p`, "acme ", "method not allowed: govtl.point.X"},
+		{"print struct with allowed fields",
+			NewSecurityPolicy().AllowType(point{}, "X", "Y"), `$p`, "{X:1, Y:2}", ""},
+		{"print struct not allowed",
+			NewSecurityPolicy().AllowType(&account{}), `$a.name $p`, "acme ", "method not allowed: govtl.point.X"},
+		{"print stringers",
+			nil, `$tok $flag`, "token flag", ""},
+		{"deny String",
+			NewSecurityPolicy().DenyType(&token{}, "String"), `$a.name $tok`, "acme ", "method not allowed: *govtl.token.String"},
+		{"deny String of value",
+			NewSecurityPolicy().DenyType(flag(false), "String"), `$a.name $flag`, "acme ", "method not allowed: govtl.flag.String"},
+		{"String is not allowed",
+			NewSecurityPolicy().AllowType(&account{}), `$a.name $tok`, "acme ", "method not allowed: *govtl.token.String"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			require.NoError(t, err)
+			tmpl.WithSecurityPolicy(test.policy)
+			var b bytes.Buffer
+			data := _m{"a": &account{"acme", 100}, "p": &point{1, 2}, "now": now, "tok": &token{}, "flag": flag(true)}
+			err = tmpl.Execute(&b, data)
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, unposErr(err), test.expectErr)
+				var nae *NotAllowedError
+				assert.True(t, errors.As(err, &nae))
+			}
+			assert.Equal(t, test.expect, b.String())
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var dateStyles = map[string]string{
+	"short":  "1/2/06 3:04 PM",
+	"medium": "Jan 2, 2006, 3:04:05 PM",
+	"long":   "January 2, 2006 at 3:04:05 PM MST",
+	"full":   "Monday, January 2, 2006 at 3:04:05 PM MST",
+	"iso":    time.RFC3339,
+}
+
+// Date is $date, it formats, parses and shifts time.Time values. Formats
+// are SimpleDateFormat patterns like "yyyy-MM-dd HH:mm" or one of the named
+// styles: "short", "medium", "long", "full" and "iso"
+type Date struct {
+	loc *time.Location
+	now func() time.Time
+}
+
+// NewDate returns Date working in loc, nil means local time
+func NewDate(loc *time.Location) *Date {
+	if loc == nil {
+		loc = time.Local
+	}
+	return &Date{loc, time.Now}
+}
+
+// WithClock sets the function returning the current time
+func (d *Date) WithClock(now func() time.Time) *Date {
+	d.now = now
+	return d
+}
+
+// Now returns the current time
+func (d *Date) Now() time.Time {
+	return d.now().In(d.loc)
+}
+
+// Get formats the current time
+func (d *Date) Get(format string) (string, error) {
+	return d.Format(format, d.Now())
+}
+
+func (d *Date) Format(format string, t time.Time) (string, error) {
+	parts, err := dateParts(format)
+	if err != nil {
+		return "", err
+	}
+	t = t.In(d.loc)
+	var b strings.Builder
+	for _, p := range parts {
+		if p.literal {
+			b.WriteString(p.text)
+		} else {
+			b.WriteString(t.Format(p.text))
+		}
+	}
+	return b.String(), nil
+}
+
+// Parse parses s according to format, time without zone is in the location
+// of Date. Literal text of the format could not contain digits, '_' and
+// words which are a part of the layout of time package, like Jan or PM
+func (d *Date) Parse(format, s string) (time.Time, error) {
+	layout, err := dateLayout(format)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.ParseInLocation(layout, s, d.loc)
+}
+
+// Add adds duration like "1h30m" to t
+func (d *Date) Add(t time.Time, duration string) (time.Time, error) {
+	dur, err := time.ParseDuration(duration)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(dur), nil
+}
+
+func (d *Date) AddDays(t time.Time, n int) time.Time { return t.AddDate(0, 0, n) }
+
+func (d *Date) AddMonths(t time.Time, n int) time.Time { return t.AddDate(0, n, 0) }
+
+func (d *Date) AddYears(t time.Time, n int) time.Time { return t.AddDate(n, 0, 0) }
+
+// Difference returns duration from a to b like "26h0m0s"
+func (d *Date) Difference(a, b time.Time) string {
+	return b.Sub(a).String()
+}
+
+// DaysBetween returns number of whole days from a to b
+func (d *Date) DaysBetween(a, b time.Time) int {
+	return int(b.Sub(a) / (24 * time.Hour))
+}
+
+// datePart is either the layout of time package or literal text
+type datePart struct {
+	text    string
+	literal bool
+}
+
+// layoutWords are words which time package reads as a part of the layout
+var layoutWords = []string{"Jan", "Mon", "MST", "PM", "pm"}
+
+// dateLayout converts SimpleDateFormat pattern to the layout of time
+// package, literal text which would be read as a part of the layout is
+// rejected
+func dateLayout(format string) (string, error) {
+	parts, err := dateParts(format)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for i, p := range parts {
+		if p.literal {
+			prev, next := i > 0 && endsWithLetter(parts[i-1].text), i < len(parts)-1 && isLetter(parts[i+1].text[0])
+			if strings.ContainsAny(p.text, "0123456789_") || containsAny(p.text, layoutWords) ||
+				prev && isLetter(p.text[0]) || next && endsWithLetter(p.text) {
+				return "", fmt.Errorf("literal %q cannot be used for parsing with date format %q", p.text, format)
+			}
+		}
+		b.WriteString(p.text)
+	}
+	return b.String(), nil
+}
+
+// dateParts splits SimpleDateFormat pattern into layouts of single fields
+// and literal text between them
+func dateParts(format string) ([]datePart, error) {
+	if l, ok := dateStyles[format]; ok {
+		return []datePart{{text: l}}, nil
+	}
+	var (
+		parts []datePart
+		lit   strings.Builder
+	)
+	flush := func() {
+		if lit.Len() > 0 {
+			parts = append(parts, datePart{lit.String(), true})
+			lit.Reset()
+		}
+	}
+	for i := 0; i < len(format); {
+		c := format[i]
+		switch {
+		case c == '\'':
+			// quoted text, '' is a single quote both inside and outside
+			i++
+			for {
+				end := strings.IndexByte(format[i:], '\'')
+				if end < 0 {
+					return nil, fmt.Errorf("unterminated quote in date format %q", format)
+				}
+				lit.WriteString(format[i : i+end])
+				i += end + 1
+				if i == len(format) || format[i] != '\'' {
+					break
+				}
+				lit.WriteByte('\'')
+				i++
+			}
+			continue
+		case !isLetter(c):
+			lit.WriteByte(c)
+			i++
+			continue
+		}
+		n := 1
+		for i+n < len(format) && format[i+n] == c {
+			n++
+		}
+		var l string
+		switch c {
+		case 'y':
+			l = pick(n, "2006", "06", "2006")
+		case 'M':
+			l = pick(n, "1", "01", "Jan", "January")
+		case 'd':
+			l = pick(n, "2", "02")
+		case 'E':
+			l = pick(n, "Mon", "Mon", "Mon", "Monday")
+		case 'H':
+			l = "15"
+		case 'h':
+			l = pick(n, "3", "03")
+		case 'm':
+			l = pick(n, "4", "04")
+		case 's':
+			l = pick(n, "5", "05")
+		case 'S':
+			// fraction is a single field with the dot before it
+			text := lit.String()
+			if !strings.HasSuffix(text, ".") {
+				return nil, fmt.Errorf("fraction of second must follow '.' in date format %q", format)
+			}
+			lit.Reset()
+			lit.WriteString(strings.TrimSuffix(text, "."))
+			l = "." + strings.Repeat("0", n)
+		case 'a':
+			l = "PM"
+		case 'z':
+			l = "MST"
+		case 'Z':
+			l = "-0700"
+		case 'X':
+			l = pick(n, "Z07", "Z0700", "Z07:00")
+		default:
+			return nil, fmt.Errorf("unsupported letter %q in date format %q", c, format)
+		}
+		flush()
+		parts = append(parts, datePart{text: l})
+		i += n
+	}
+	flush()
+	return parts, nil
+}
+
+func isLetter(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
+
+func endsWithLetter(s string) bool {
+	return s != "" && isLetter(s[len(s)-1])
+}
+
+func containsAny(s string, words []string) bool {
+	for _, w := range words {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// pick returns variant for the letter repeated n times, the last variant is
+// used for longer repeats
+func pick(n int, variants ...string) string {
+	if n > len(variants) {
+		n = len(variants)
+	}
+	return variants[n-1]
+}
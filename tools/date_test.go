@@ -0,0 +1,38 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDate(t *testing.T) {
+	now := time.Date(2021, 3, 7, 14, 5, 9, 123000000, time.UTC)
+	tests := []toolTest{
+		{"now", `$date.now().year()`, "2021", ""},
+		{"get", `$date.get('yyyy-MM-dd HH:mm:ss.SSS')`, "2021-03-07 14:05:09.123", ""},
+		{"format", `$date.format('EEE, d MMM yy h:mm a', $t)`, "Sun, 7 Mar 21 2:05 PM", ""},
+		{"long names", `$date.format('EEEE, MMMM dd', $t)`, "Sunday, March 07", ""},
+		{"quoted", `$date.format("yyyy-MM-dd'T'HH 'o''clock'", $t)`, "2021-03-07T14 o'clock", ""},
+		{"literal tokens", `$date.format("'Jan 2, 2006' dd 'at 15:04 PM MST' HH'h'", $t)`, "Jan 2, 2006 07 at 15:04 PM MST 14h", ""},
+		{"digits", `$date.format('yyyy/1/MM-2_3', $t)`, "2021/1/03-2_3", ""},
+		{"styles", `$date.format('short', $t) | $date.format('medium', $t) | $date.format('iso', $t)`,
+			"3/7/21 2:05 PM | Mar 7, 2021, 2:05:09 PM | 2021-03-07T14:05:09Z", ""},
+		{"zone", `$date.format('HH:mm Z', $t)`, "14:05 +0000", ""},
+		{"parse", `#set($d = $date.parse('dd.MM.yyyy', '01.02.2020'))$d.year() $d.month() $d.day()`, "2020 2 1", ""},
+		{"parse quoted", `#set($d = $date.parse("yyyy-MM-dd'T'HH", '2020-01-02T03'))$d.day() $d.hour()`, "2 3", ""},
+		{"parse fraction", `#set($d = $date.parse('HH:mm:ss.SSS', '01:02:03.456'))$d.nanosecond()`, "456000000", ""},
+		{"parse digits", `$date.parse("yyyy 'at 15' HH", '2020 at 15 01')`, "", `literal " at 15 " cannot be used for parsing with date format "yyyy 'at 15' HH"`},
+		{"parse layout word", `$date.parse("'Mon' yyyy", 'Mon 2020')`, "", `literal "Mon " cannot be used for parsing with date format "'Mon' yyyy"`},
+		{"parse adjacent letters", `$date.parse("MMMM'e'", 'Marche')`, "", `literal "e" cannot be used for parsing with date format "MMMM'e'"`},
+		{"add", `$date.format('yyyy-MM-dd HH:mm', $date.add($t, '1h30m'))`, "2021-03-07 15:35", ""},
+		{"add dates", `$date.format('yyyy-MM-dd', $date.addDays($t, 30)) $date.format('yyyy-MM-dd', $date.addMonths($t, -3)) $date.format('yyyy', $date.addYears($t, 1))`,
+			"2021-04-06 2020-12-07 2022", ""},
+		{"difference", `$date.difference($t, $date.addDays($t, 2)) $date.daysBetween($t, $date.addDays($t, 10))`, "48h0m0s 10", ""},
+		{"bad format", `$date.format('yyyy-QQ', $t)`, "", `unsupported letter 'Q' in date format "yyyy-QQ"`},
+		{"unterminated quote", `$date.format("yyyy 'x", $t)`, "", `unterminated quote in date format "yyyy 'x"`},
+		{"bad parse", `$date.parse('yyyy', 'x')`, "", `cannot parse "x"`},
+		{"bad duration", `$date.add($t, 'x')`, "", `invalid duration`},
+	}
+	date := NewDate(time.UTC).WithClock(func() time.Time { return now })
+	runTemplates(t, tests, map[string]interface{}{"date": date}, map[string]interface{}{"t": now})
+}
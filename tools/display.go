@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	govtl "github.com/iron-s/go-vtl"
+)
+
+// Display is $display, it helps to present values: truncates strings, joins
+// lists and substitutes missing values
+type Display struct{}
+
+// Truncate shortens v to max characters including the suffix, which is "..."
+// if not given
+func (Display) Truncate(v interface{}, max int, suffix ...string) string {
+	s := toString(v)
+	end := "..."
+	if len(suffix) > 0 {
+		end = suffix[0]
+	}
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	keep := max - utf8.RuneCountInString(end)
+	if keep < 0 {
+		keep = 0
+	}
+	return string([]rune(s)[:keep]) + end
+}
+
+// List joins elements of list with ", " and the last one with " and ",
+// delims could override them: the first one is the delimiter and the second
+// is the final delimiter
+func (Display) List(list interface{}, delims ...string) (string, error) {
+	delim, final := ", ", " and "
+	if len(delims) > 0 {
+		delim, final = delims[0], delims[0]
+	}
+	if len(delims) > 1 {
+		final = delims[1]
+	}
+	items, err := elements(list)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for i, item := range items {
+		switch {
+		case i == 0:
+		case i == len(items)-1:
+			b.WriteString(final)
+		default:
+			b.WriteString(delim)
+		}
+		b.WriteString(toString(item))
+	}
+	return b.String(), nil
+}
+
+// Alt returns alt if v is nil
+func (Display) Alt(v, alt interface{}) interface{} {
+	if v == nil {
+		return alt
+	}
+	return v
+}
+
+// Capitalize makes the first letter of v upper case
+func (Display) Capitalize(v interface{}) string {
+	s := toString(v)
+	if s == "" {
+		return s
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[n:]
+}
+
+// Uncapitalize makes the first letter of v lower case
+func (Display) Uncapitalize(v interface{}) string {
+	s := toString(v)
+	if s == "" {
+		return s
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
+
+// Plural returns singular if n is 1, otherwise plural, which is singular
+// with s appended if not given
+func (Display) Plural(n int, singular string, plural ...string) string {
+	if n == 1 {
+		return singular
+	}
+	if len(plural) > 0 {
+		return plural[0]
+	}
+	return singular + "s"
+}
+
+// elements returns elements of template list or Go slice or array
+func elements(list interface{}) ([]interface{}, error) {
+	if it, ok := list.(govtl.Iterable); ok {
+		var items []interface{}
+		for i := it.Iterator(); i.HasNext(); {
+			v, err := i.Next()
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		return items, nil
+	}
+	rv := reflect.ValueOf(list)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			items[i] = rv.Index(i).Interface()
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("not a list: %s", kind(list))
+}
@@ -0,0 +1,18 @@
+package tools
+
+import "testing"
+
+func TestDisplay(t *testing.T) {
+	tests := []toolTest{
+		{"truncate", `$display.truncate('Hello, world', 8) $display.truncate('short', 8) $display.truncate('Привет, мир', 7, '…')`, "Hello... short Привет…", ""},
+		{"list", `$display.list($l) / $display.list(['x', 'y']) / $display.list(['x'])`, "a, b and c / x and y / x", ""},
+		{"list delims", `$display.list([1, 2, 3], ' | ') $display.list([1, 2, 3], '; ', ' or ')`, "1 | 2 | 3 1; 2 or 3", ""},
+		{"list of keys", `$display.list($m.keySet())`, "k", ""},
+		{"alt", `$display.alt($n, '-') $display.alt($m.k, '-') $display.alt($m.missing, 'none')`, "- v none", ""},
+		{"capitalize", `$display.capitalize('élan') $display.uncapitalize('World') [$display.capitalize('')]`, "Élan world []", ""},
+		{"plural", `1 $display.plural(1, 'item'), 3 $display.plural(3, 'item'), 2 $display.plural(2, 'mouse', 'mice')`, "1 item, 3 items, 2 mice", ""},
+		{"not a list", `$display.list(1)`, "", "not a list: int64"},
+	}
+	data := map[string]interface{}{"l": []string{"a", "b", "c"}, "m": map[string]interface{}{"k": "v"}, "n": nil}
+	runTemplates(t, tests, map[string]interface{}{"display": Display{}}, data)
+}
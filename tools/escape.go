@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+
+	govtl "github.com/iron-s/go-vtl"
+)
+
+// Escape is $esc, it escapes values for various contexts. Results are
+// govtl.SafeString, so they are not escaped again by the template escaping
+// mode
+type Escape struct{}
+
+func (Escape) Html(v interface{}) govtl.SafeString {
+	return govtl.SafeString(govtl.EscapeHTML.Escape(toString(v)))
+}
+
+func (Escape) Xml(v interface{}) govtl.SafeString {
+	return govtl.SafeString(govtl.EscapeXML.Escape(toString(v)))
+}
+
+// Json escapes v for the inside of JSON string literal, <, > and & are
+// escaped too, so the result is safe inside HTML script element
+func (Escape) Json(v interface{}) govtl.SafeString {
+	b, _ := json.Marshal(toString(v))
+	return govtl.SafeString(b[1 : len(b)-1])
+}
+
+// Javascript escapes v for the inside of JavaScript string literal
+func (Escape) Javascript(v interface{}) govtl.SafeString {
+	return govtl.SafeString(govtl.EscapeJS.Escape(toString(v)))
+}
+
+// Url escapes v for query component, space is encoded as +
+func (Escape) Url(v interface{}) govtl.SafeString {
+	return govtl.SafeString(url.QueryEscape(toString(v)))
+}
+
+// Sql escapes v for the inside of SQL string literal by doubling single
+// quotes
+func (Escape) Sql(v interface{}) govtl.SafeString {
+	return govtl.SafeString(strings.Replace(toString(v), "'", "''", -1))
+}
+
+// $esc.d, $esc.h, $esc.b, $esc.q, $esc.s and $esc.e are characters which
+// are hard to write literally in the template: $, #, \, ", ' and !
+func (Escape) GetD() govtl.SafeString { return "$" }
+func (Escape) GetH() govtl.SafeString { return "#" }
+func (Escape) GetB() govtl.SafeString { return `\` }
+func (Escape) GetQ() govtl.SafeString { return `"` }
+func (Escape) GetS() govtl.SafeString { return "'" }
+func (Escape) GetE() govtl.SafeString { return "!" }
@@ -0,0 +1,33 @@
+package tools
+
+import (
+	"bytes"
+	"testing"
+
+	govtl "github.com/iron-s/go-vtl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscape(t *testing.T) {
+	tests := []toolTest{
+		{"html", `$esc.html($s)`, `&lt;a href=&#34;x&#34;&gt;Tom &amp; Jerry&#39;s&lt;/a&gt;`, ""},
+		{"xml", `$esc.xml($s)`, `&lt;a href=&quot;x&quot;&gt;Tom &amp; Jerry&apos;s&lt;/a&gt;`, ""},
+		{"json", `{"v": "$esc.json($j)"}`, `{"v": "line\nnext \"quoted\" \\ \u003ctag\u003e"}`, ""},
+		{"javascript", `'$esc.javascript("it's")'`, `'it\'s'`, ""},
+		{"url", `?q=$esc.url('a b&c=d')`, `?q=a+b%26c%3Dd`, ""},
+		{"sql", `'$esc.sql("O'Brien")'`, `'O''Brien'`, ""},
+		{"numbers", `$esc.html(42)`, `42`, ""},
+		{"characters", `${esc.d}x $esc.h $esc.b $esc.q $esc.s $esc.e`, `$x # \ " ' !`, ""},
+	}
+	data := map[string]interface{}{"s": `<a href="x">Tom & Jerry's</a>`, "j": "line\nnext \"quoted\" \\ <tag>"}
+	runTemplates(t, tests, map[string]interface{}{"esc": Escape{}}, data)
+}
+
+func TestEscapeNotEscapedTwice(t *testing.T) {
+	tmpl, err := govtl.Parse(`$esc.html($s) $s`, "", "")
+	require.NoError(t, err)
+	var b bytes.Buffer
+	require.NoError(t, tmpl.WithEscape(govtl.EscapeHTML).Execute(&b, map[string]interface{}{"esc": Escape{}, "s": "<&>"}))
+	assert.Equal(t, "&lt;&amp;&gt; &lt;&amp;&gt;", b.String())
+}
@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Math is $math, it provides rounding, min/max and random numbers. Integer
+// arguments give integer results where it makes sense
+type Math struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewMath returns Math with random numbers taken from src, nil src is
+// seeded with the current time
+func NewMath(src rand.Source) *Math {
+	if src == nil {
+		src = rand.NewSource(time.Now().UnixNano())
+	}
+	return &Math{rnd: rand.New(src)}
+}
+
+// Round rounds n to the nearest integer, halves are rounded up
+func (m *Math) Round(n interface{}) (int64, error) {
+	f, _, err := toFloat(n)
+	return int64(math.Floor(f + 0.5)), err
+}
+
+// RoundTo rounds n to the decimals places after the point
+func (m *Math) RoundTo(decimals int, n interface{}) (float64, error) {
+	f, _, err := toFloat(n)
+	p := math.Pow10(decimals)
+	return math.Floor(f*p+0.5) / p, err
+}
+
+func (m *Math) Floor(n interface{}) (int64, error) {
+	f, _, err := toFloat(n)
+	return int64(math.Floor(f)), err
+}
+
+func (m *Math) Ceil(n interface{}) (int64, error) {
+	f, _, err := toFloat(n)
+	return int64(math.Ceil(f)), err
+}
+
+func (m *Math) Abs(n interface{}) (interface{}, error) {
+	f, isInt, err := toFloat(n)
+	if err != nil {
+		return nil, err
+	}
+	return number(math.Abs(f), isInt), nil
+}
+
+// Max returns the largest of nums
+func (m *Math) Max(nums ...interface{}) (interface{}, error) {
+	return extreme(nums, func(a, b float64) bool { return a > b })
+}
+
+// Min returns the smallest of nums
+func (m *Math) Min(nums ...interface{}) (interface{}, error) {
+	return extreme(nums, func(a, b float64) bool { return a < b })
+}
+
+// Random returns a random number in [0, 1) if called without arguments or
+// in [min, max) if called with two, the number is an integer if both bounds
+// are integers
+func (m *Math) Random(bounds ...interface{}) (interface{}, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch len(bounds) {
+	case 0:
+		return m.rnd.Float64(), nil
+	case 2:
+		min, minInt, err := toFloat(bounds[0])
+		if err != nil {
+			return nil, err
+		}
+		max, maxInt, err := toFloat(bounds[1])
+		if err != nil {
+			return nil, err
+		}
+		if max <= min {
+			return nil, errors.New("max must be greater than min")
+		}
+		if minInt && maxInt {
+			return int64(min) + m.rnd.Int63n(int64(max)-int64(min)), nil
+		}
+		return min + m.rnd.Float64()*(max-min), nil
+	}
+	return nil, errors.New("random accepts either no or two arguments")
+}
+
+func extreme(nums []interface{}, better func(a, b float64) bool) (interface{}, error) {
+	if len(nums) == 0 {
+		return nil, errors.New("no numbers given")
+	}
+	var (
+		res   float64
+		isInt = true
+	)
+	for i, n := range nums {
+		f, fInt, err := toFloat(n)
+		if err != nil {
+			return nil, err
+		}
+		isInt = isInt && fInt
+		if i == 0 || better(f, res) {
+			res = f
+		}
+	}
+	return number(res, isInt), nil
+}
+
+func number(f float64, isInt bool) interface{} {
+	if isInt {
+		return int64(f)
+	}
+	return f
+}
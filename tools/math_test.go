@@ -0,0 +1,41 @@
+package tools
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMath(t *testing.T) {
+	tests := []toolTest{
+		{"round", `$math.round(2.5) $math.round(-2.5) $math.round(3) $math.round('1.4')`, "3 -2 3 1", ""},
+		{"round to", `$math.roundTo(2, 3.14159) $math.roundTo(0, 2.5)`, "3.14 3.0", ""},
+		{"floor and ceil", `$math.floor(2.7) $math.ceil(2.1) $math.floor(-2.1) $math.ceil(-2.7)`, "2 3 -3 -2", ""},
+		{"abs", `$math.abs(-3) $math.abs(-1.5)`, "3 1.5", ""},
+		{"max", `$math.max(1, 5, 3) $math.max(1, 2.5) $math.max($n)`, "5 2.5 7", ""},
+		{"min", `$math.min(4, -2, 3) $math.min(1.5, 2)`, "-2 1.5", ""},
+		{"random in range", `#foreach($i in [1..20])#set($r = $math.random(1, 3))#if($r < 1 || $r >= 3)bad#end#end`, "", ""},
+		{"random float", `#set($r = $math.random())#if($r >= 0 && $r < 1)ok#end`, "ok", ""},
+		{"not a number", `$math.round('x')`, "", `not a number: "x"`},
+		{"bad random bounds", `$math.random(3, 1)`, "", "max must be greater than min"},
+	}
+	runTemplates(t, tests, map[string]interface{}{"math": NewMath(nil)}, map[string]interface{}{"n": 7})
+}
+
+func TestMathRandomSeed(t *testing.T) {
+	vtl := `#foreach($i in [1..5])$math.random(0, 100) #end`
+	rnd := rand.New(rand.NewSource(42))
+	var expect strings.Builder
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(&expect, "%d ", rnd.Int63n(100))
+	}
+	for i := 0; i < 2; i++ {
+		out, err := render(t, vtl, map[string]interface{}{"math": NewMath(rand.NewSource(42))}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, expect.String(), out)
+	}
+}
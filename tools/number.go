@@ -0,0 +1,170 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale is used by tools created by New
+const DefaultLocale = "en_US"
+
+type locale struct {
+	decimal, group string
+	// patterns for currency and percent styles
+	currency, percent string
+}
+
+// separators are non-breaking spaces where locales use spaces
+var locales = map[string]locale{
+	"en_US": {".", ",", "$#,##0.00", "#,##0%"},
+	"en_GB": {".", ",", "£#,##0.00", "#,##0%"},
+	"de_DE": {",", ".", "#,##0.00\u00a0€", "#,##0\u00a0%"},
+	"fr_FR": {",", "\u202f", "#,##0.00\u00a0€", "#,##0\u00a0%"},
+	"es_ES": {",", ".", "#,##0.00\u00a0€", "#,##0\u00a0%"},
+	"it_IT": {",", ".", "#,##0.00\u00a0€", "#,##0%"},
+	"ru_RU": {",", "\u00a0", "#,##0.00\u00a0₽", "#,##0\u00a0%"},
+	"ja_JP": {".", ",", "￥#,##0", "#,##0%"},
+}
+
+func findLocale(name string) (locale, error) {
+	l, ok := locales[strings.Replace(name, "-", "_", -1)]
+	if !ok {
+		return l, fmt.Errorf("unknown locale %q", name)
+	}
+	return l, nil
+}
+
+// Number is $number, it formats numbers with DecimalFormat-like patterns
+// such as "#,##0.00" or with one of the named styles: "number", "integer",
+// "currency" and "percent"
+type Number struct {
+	locale string
+}
+
+// NewNumber returns Number formatting for locale, e.g. "en_US" or "de-DE"
+func NewNumber(locale string) *Number {
+	return &Number{locale}
+}
+
+// Format formats n according to format in the default locale
+func (t *Number) Format(format string, n interface{}) (string, error) {
+	return t.FormatLocale(format, n, t.locale)
+}
+
+// FormatLocale formats n according to format in the locale loc
+func (t *Number) FormatLocale(format string, n interface{}, loc string) (string, error) {
+	l, err := findLocale(loc)
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case "number", "default":
+		format = "#,##0.###"
+	case "integer":
+		format = "#,##0"
+	case "currency":
+		format = l.currency
+	case "percent":
+		format = l.percent
+	}
+	p, err := parsePattern(format)
+	if err != nil {
+		return "", err
+	}
+	f, _, err := toFloat(n)
+	if err != nil {
+		return "", err
+	}
+	return p.format(f, l), nil
+}
+
+func (t *Number) Integer(n interface{}) (string, error) { return t.Format("integer", n) }
+
+func (t *Number) Currency(n interface{}) (string, error) { return t.Format("currency", n) }
+
+func (t *Number) Percent(n interface{}) (string, error) { return t.Format("percent", n) }
+
+// ToNumber converts numeric string to number, integer if possible
+func (t *Number) ToNumber(v interface{}) (interface{}, error) {
+	f, isInt, err := toFloat(v)
+	if err != nil {
+		return nil, err
+	}
+	return number(f, isInt), nil
+}
+
+type pattern struct {
+	prefix, suffix   string
+	minInt           int
+	minFrac, maxFrac int
+	// number of digits in group, 0 if no grouping
+	group      int
+	multiplier float64
+}
+
+// parsePattern parses the subset of DecimalFormat patterns: optional prefix
+// and suffix, digits (0 and #) with grouping separator and decimal point
+func parsePattern(s string) (pattern, error) {
+	p := pattern{multiplier: 1}
+	start := strings.IndexAny(s, "#0,.")
+	if start < 0 {
+		return p, fmt.Errorf("invalid number pattern %q", s)
+	}
+	end := strings.LastIndexAny(s, "#0,.") + 1
+	p.prefix, p.suffix = s[:start], s[end:]
+	if strings.Contains(p.prefix+p.suffix, "%") {
+		p.multiplier = 100
+	}
+	num := s[start:end]
+	intPart, fracPart := num, ""
+	if i := strings.IndexByte(num, '.'); i >= 0 {
+		intPart, fracPart = num[:i], num[i+1:]
+	}
+	if strings.ContainsAny(fracPart, ".,") {
+		return p, fmt.Errorf("invalid number pattern %q", s)
+	}
+	p.minInt = strings.Count(intPart, "0")
+	if i := strings.LastIndexByte(intPart, ','); i >= 0 {
+		p.group = len(intPart) - i - 1
+	}
+	p.minFrac = strings.Count(fracPart, "0")
+	p.maxFrac = len(fracPart)
+	return p, nil
+}
+
+func (p pattern) format(f float64, l locale) string {
+	f *= p.multiplier
+	s := strconv.FormatFloat(math.Abs(f), 'f', p.maxFrac, 64)
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	for len(fracPart) > p.minFrac && fracPart[len(fracPart)-1] == '0' {
+		fracPart = fracPart[:len(fracPart)-1]
+	}
+	if intPart == "0" && p.minInt == 0 && fracPart != "" {
+		intPart = ""
+	}
+	if len(intPart) < p.minInt {
+		intPart = strings.Repeat("0", p.minInt-len(intPart)) + intPart
+	}
+	var b strings.Builder
+	if f < 0 && strings.Trim(intPart+fracPart, "0") != "" {
+		b.WriteByte('-')
+	}
+	b.WriteString(p.prefix)
+	for i, c := range intPart {
+		if i > 0 && p.group > 0 && (len(intPart)-i)%p.group == 0 {
+			b.WriteString(l.group)
+		}
+		b.WriteRune(c)
+	}
+	if fracPart != "" {
+		b.WriteString(l.decimal)
+		b.WriteString(fracPart)
+	}
+	b.WriteString(p.suffix)
+	return b.String()
+}
@@ -0,0 +1,22 @@
+package tools
+
+import "testing"
+
+func TestNumber(t *testing.T) {
+	tests := []toolTest{
+		{"default", `$number.format('number', 1234567.8912)`, "1,234,567.891", ""},
+		{"pattern", `$number.format('#,##0.00', 1234.5) $number.format('0.#', 0.25) $number.format('#.##', 0.5) $number.format('000', 7)`, "1,234.50 0.2 .5 007", ""},
+		{"negative", `$number.format('#,##0.00', -1234.567) $number.format('0', -0.2)`, "-1,234.57 0", ""},
+		{"prefix and suffix", `$number.format('# items', 3) $number.format('#0.0%', 0.1234)`, "3 items 12.3%", ""},
+		{"integer", `$number.integer(1234.7) $number.integer($n)`, "1,235 1,000,000", ""},
+		{"currency", `$number.currency(1234.5)`, "$1,234.50", ""},
+		{"percent", `$number.percent(0.256)`, "26%", ""},
+		{"locale", `$number.formatLocale('currency', 1234.5, 'de_DE') $number.formatLocale('#,##0.00', 1234.5, 'fr-FR')`, "1.234,50 € 1 234,50", ""},
+		{"string", `$number.format('0.00', '3.14159')`, "3.14", ""},
+		{"to number", `#set($x = $number.toNumber('41'))#set($x = $x + 1)$x`, "42", ""},
+		{"unknown locale", `$number.formatLocale('number', 1, 'xx')`, "", `unknown locale "xx"`},
+		{"bad pattern", `$number.format('abc', 1)`, "", `invalid number pattern "abc"`},
+		{"not a number", `$number.format('0', [1])`, "", "not a number: *govtl.Slice"},
+	}
+	runTemplates(t, tests, map[string]interface{}{"number": NewNumber("en_US")}, map[string]interface{}{"n": 1000000})
+}
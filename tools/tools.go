@@ -0,0 +1,83 @@
+// Package tools provides helpers for templates modeled after Velocity Tools.
+// Tools are plain values, they are made available to the template by adding
+// them to the data passed to Execute:
+//
+//	tmpl.Execute(w, tools.Merge(data, tools.New()))
+package tools
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// New returns tools with default settings under their conventional names:
+// $math, $number, $date, $esc and $display
+func New() map[string]interface{} {
+	return map[string]interface{}{
+		"math":    NewMath(nil),
+		"number":  NewNumber(DefaultLocale),
+		"date":    NewDate(nil),
+		"esc":     Escape{},
+		"display": Display{},
+	}
+}
+
+// Merge adds tools to data, values already present in data are kept. Data
+// is modified and returned, nil data is allocated
+func Merge(data, tools map[string]interface{}) map[string]interface{} {
+	if data == nil {
+		data = make(map[string]interface{}, len(tools))
+	}
+	for k, v := range tools {
+		if _, ok := data[k]; !ok {
+			data[k] = v
+		}
+	}
+	return data
+}
+
+// toFloat converts number or numeric string to float64, isInt reports
+// whether the value was an integer
+func toFloat(v interface{}) (f float64, isInt bool, err error) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true, nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), false, nil
+	case reflect.String:
+		s := strings.TrimSpace(rv.String())
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return float64(i), true, nil
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("not a number: %q", rv.String())
+		}
+		return f, false, nil
+	}
+	return 0, false, fmt.Errorf("not a number: %s", kind(v))
+}
+
+// toString converts v to string the way it would be printed in the text
+func toString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.String {
+		return rv.String()
+	}
+	return fmt.Sprint(v)
+}
+
+func kind(v interface{}) string {
+	if v == nil {
+		return "nil"
+	}
+	return reflect.TypeOf(v).String()
+}
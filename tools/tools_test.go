@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"bytes"
+	"testing"
+
+	govtl "github.com/iron-s/go-vtl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type toolTest struct {
+	name      string
+	tmpl      string
+	expect    string
+	expectErr string
+}
+
+// render executes template with tools merged into data
+func render(t *testing.T, vtl string, tools, data map[string]interface{}) (string, error) {
+	tmpl, err := govtl.Parse(vtl, "", "")
+	require.NoError(t, err)
+	var b bytes.Buffer
+	err = tmpl.Execute(&b, Merge(Merge(nil, data), tools))
+	return b.String(), err
+}
+
+func runTemplates(t *testing.T, tests []toolTest, tools, data map[string]interface{}) {
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := render(t, test.tmpl, tools, data)
+			if test.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expect, out)
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	data := map[string]interface{}{"math": 1}
+	merged := Merge(data, New())
+	assert.Equal(t, 1, merged["math"])
+	for _, name := range []string{"number", "date", "esc", "display"} {
+		assert.Contains(t, merged, name)
+	}
+	assert.Len(t, Merge(nil, New()), 5)
+
+	runTemplates(t, []toolTest{
+		{"all tools", `$number.integer(1234.5) $esc.html('<') $display.plural(2, 'tool')`, "1,234 &lt; tools", ""},
+	}, New(), nil)
+}
@@ -0,0 +1,413 @@
+package govtl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TypeErrors is returned by CheckType and CheckTypes, it holds all problems
+// found in the template
+type TypeErrors []*Error
+
+func (e TypeErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i := range e {
+		msgs[i] = e[i].Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+var (
+	strType      = reflect.TypeOf(Str(""))
+	foreachType  = reflect.TypeOf(&foreach{})
+	int64Type    = reflect.TypeOf(int64(0))
+	float64Type  = reflect.TypeOf(float64(0))
+	boolType     = reflect.TypeOf(false)
+	listLitType  = reflect.TypeOf([]interface{}{})
+	mapLitType   = reflect.TypeOf(map[string]interface{}{})
+	rangeValType = reflect.TypeOf(&Range{})
+)
+
+// CheckType checks references of the template against data of type typ:
+// variables are looked up in typ the same way as properties, so for a
+// struct they are its fields, e.g. $user is the field User. Access chains
+// are resolved with the lookup rules of execution. References which could
+// not be resolved, calls with wrong number or types of arguments are
+// reported. Values of interface types are not checked further
+func (t *Template) CheckType(typ reflect.Type) error {
+	c := &checker{root: typ, funcs: t.funcs, name: t.name}
+	return c.check(t.tree)
+}
+
+// CheckTypes is like CheckType, but variables and their types are given by
+// vars
+func (t *Template) CheckTypes(vars map[string]reflect.Type) error {
+	c := &checker{vars: vars, funcs: t.funcs, name: t.name}
+	return c.check(t.tree)
+}
+
+type checker struct {
+	root  reflect.Type
+	vars  map[string]reflect.Type
+	funcs funcSet
+	errs  TypeErrors
+	// name of the checked template for errors, templates included with
+	// #parse are not checked
+	name string
+	// inside of the macro body variables could come from the caller
+	inMacro bool
+	undefOk bool
+}
+
+// types of bound variables, nil type is unknown
+type typeScope map[string]reflect.Type
+
+func (s typeScope) with() typeScope {
+	c := make(typeScope, len(s))
+	for k, v := range s {
+		c[k] = v
+	}
+	return c
+}
+
+func (c *checker) check(tree []Node) error {
+	bound := typeScope{}
+	if c.funcs != nil {
+		bound[FuncsVar] = funcSetType
+	}
+	c.walk(tree, bound)
+	if len(c.errs) > 0 {
+		return c.errs
+	}
+	return nil
+}
+
+func (c *checker) errorf(pos Pos, format string, args ...interface{}) {
+	c.errs = append(c.errs, &Error{Name: c.name, Line: pos.line, Column: pos.col, Err: fmt.Errorf(format, args...)})
+}
+
+func (c *checker) walk(list []Node, bound typeScope) {
+	for _, node := range list {
+		inner := bound
+		switch n := node.(type) {
+		case *VarNode:
+			c.ref(n, bound)
+		case *SetNode:
+			typ := c.expr(n.Expr, bound)
+			if len(n.Var.Items) == 0 {
+				bound[n.Var.Name] = typ
+			} else {
+				c.ref(n.Var, bound)
+			}
+		case *IfNode:
+			// undefined variables are false in conditions
+			c.undefOk = true
+			for i := n; i != nil; i = i.Else {
+				c.expr(i.Cond, bound)
+			}
+			c.undefOk = false
+		case *ForeachNode:
+			inner = bound.with()
+			inner[n.Var.Name] = c.elem(n.Pos, c.expr(n.Iter, bound))
+			inner["foreach"] = foreachType
+		case *MacroNode:
+			inner = bound.with()
+			inner["bodyContent"] = nil
+			for _, p := range n.Assign {
+				inner[p.Name] = nil
+			}
+		case *MacroCall:
+			c.exprs(n.Vals, bound)
+		case *DirectiveNode:
+			c.exprs(n.Args, bound)
+		case *DefineNode:
+			bound[n.Var.Name] = nil
+		case *IncludeNode:
+			c.exprs(n.Names, bound)
+		case *ParseNode:
+			c.expr(n.Name, bound)
+		case *EvalNode:
+			c.expr(n.Content, bound)
+		}
+		if nested, ok := node.(NestedNode); ok {
+			_, macro := node.(*MacroNode)
+			inMacro := c.inMacro
+			c.inMacro = inMacro || macro
+			for _, items := range nested.Nested() {
+				c.walk(items, inner.with())
+			}
+			c.inMacro = inMacro
+		}
+	}
+}
+
+// elem returns type of elements of iterable typ
+func (c *checker) elem(pos Pos, typ reflect.Type) reflect.Type {
+	if typ == nil {
+		return nil
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if typ == listLitType || typ == mapLitType {
+			return nil
+		}
+		return typ.Elem()
+	case reflect.Interface:
+		return nil
+	}
+	switch typ {
+	case rangeValType:
+		return int64Type
+	case sliceType, mapType, keyViewType, valViewType, entryViewType, collIteratorType, mapIteratorType:
+		return nil
+	}
+	c.errorf(pos, "cannot iterate over %s", typ)
+	return nil
+}
+
+func (c *checker) exprs(list []*OpNode, bound typeScope) {
+	for _, e := range list {
+		c.expr(e, bound)
+	}
+}
+
+// expr checks expression and returns its type, nil if unknown
+func (c *checker) expr(e *OpNode, bound typeScope) reflect.Type {
+	if e == nil {
+		return nil
+	}
+	if e.Op != "" {
+		l := c.expr(e.Left, bound)
+		c.expr(e.Right, bound)
+		switch e.Op {
+		case "list":
+			return listLitType
+		case "map":
+			return mapLitType
+		case "range":
+			return rangeValType
+		case "eq", "ne", "le", "lt", "ge", "gt", "and", "or", "not":
+			return boolType
+		case "negate":
+			return l
+		}
+		return nil
+	}
+	switch v := e.Val.(type) {
+	case *VarNode:
+		return c.ref(v, bound)
+	case *InterpolatedNode:
+		c.walk(v.Items, bound)
+		return strType
+	case string:
+		return strType
+	case int64:
+		return int64Type
+	case float64:
+		return float64Type
+	case bool:
+		return boolType
+	case []*OpNode:
+		c.exprs(v, bound)
+	}
+	return nil
+}
+
+// ref checks the access chain of the reference and returns its type
+func (c *checker) ref(n *VarNode, bound typeScope) reflect.Type {
+	defer c.expr(n.Alt, bound)
+	typ, ok := bound[n.Name]
+	if !ok {
+		var err error
+		switch {
+		case c.vars != nil:
+			if typ, ok = c.vars[n.Name]; !ok {
+				err = fmt.Errorf("undefined var $%s", n.Name)
+			}
+		case c.root != nil:
+			typ, err = c.property(c.root, n.Name)
+			if err != nil {
+				err = fmt.Errorf("undefined var $%s", n.Name)
+			}
+		}
+		if err != nil {
+			if !c.inMacro && !c.undefOk && n.Alt == nil {
+				c.errorf(n.Pos, "%v", err)
+			}
+			return nil
+		}
+	}
+	pos := n.Pos
+	for _, item := range n.Items {
+		args := make([]reflect.Type, len(item.Args))
+		for i, a := range item.Args {
+			args[i] = c.expr(a, bound)
+		}
+		if item.Kind != AccessIndex {
+			pos = item.Pos
+		}
+		if typ == nil || typ.Kind() == reflect.Interface {
+			// nothing is known about the value
+			typ = nil
+			continue
+		}
+		var err error
+		switch item.Kind {
+		case AccessMethod:
+			typ, err = c.call(typ, item.Name, args)
+		case AccessIndex:
+			typ, err = c.call(typ, "get", args)
+		default:
+			typ, err = c.property(typ, item.Name)
+		}
+		if err != nil {
+			c.errorf(pos, "%v", err)
+			return nil
+		}
+	}
+	return typ
+}
+
+// wrappedType returns type of the value as it is seen by the template
+func wrappedType(typ reflect.Type) reflect.Type {
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		return sliceType
+	case reflect.Map:
+		return mapType
+	case reflect.String:
+		if typ == safeStringType {
+			return typ
+		}
+		return strType
+	case reflect.Ptr:
+		if w := wrappedType(typ.Elem()); w != typ.Elem() {
+			return w
+		}
+	}
+	return typ
+}
+
+// property mirrors Template.property
+func (c *checker) property(typ reflect.Type, name string) (reflect.Type, error) {
+	f := ucFirst(name)
+	base := typ
+	if base.Kind() == reflect.Ptr {
+		base = base.Elem()
+	}
+	if base.Kind() == reflect.Struct {
+		if field, ok := base.FieldByName(f); ok && field.PkgPath == "" {
+			return field.Type, nil
+		}
+	}
+	w := wrappedType(typ)
+	for _, mm := range []string{"Get", "Is"} {
+		if m, ok := w.MethodByName(mm + f); ok && m.Type.NumIn() == 1 {
+			return methodResult(m.Type), nil
+		}
+	}
+	if m, ok := w.MethodByName("Get"); ok && m.Type.NumIn() == 2 {
+		if elem, err := c.get(typ, m.Type, []reflect.Type{strType}); err == nil {
+			return elem, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot get property %s of %s value", name, typeKind(typ))
+}
+
+// call mirrors Template.call
+func (c *checker) call(typ reflect.Type, meth string, args []reflect.Type) (reflect.Type, error) {
+	if typ == funcSetType {
+		f, ok := c.funcs[meth]
+		if !ok {
+			return nil, fmt.Errorf("undefined function %s", meth)
+		}
+		if err := checkArgs(f.Type(), 0, args); err != nil {
+			return nil, fmt.Errorf("%s: %w", meth, err)
+		}
+		return methodResult(f.Type()), nil
+	}
+	trimm := ucFirst(strings.TrimPrefix(meth, "get"))
+	w := wrappedType(typ)
+	m, ok := w.MethodByName(ucFirst(meth))
+	for _, mm := range []string{"Get", "Is"} {
+		if ok {
+			break
+		}
+		m, ok = w.MethodByName(mm + trimm)
+	}
+	if ok {
+		if m.Name == "Get" {
+			return c.get(typ, m.Type, args)
+		}
+		return methodResult(m.Type), checkArgs(m.Type, 1, args)
+	}
+	base := typ
+	if base.Kind() == reflect.Ptr {
+		base = base.Elem()
+	}
+	if base.Kind() == reflect.Struct {
+		if field, ok := base.FieldByName(trimm); ok && field.PkgPath == "" {
+			return field.Type, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot call %s on %s value", meth, typeKind(typ))
+}
+
+// get checks Get method call, for Go slices and maps the type of elements
+// is known
+func (c *checker) get(typ, m reflect.Type, args []reflect.Type) (reflect.Type, error) {
+	if err := checkArgs(m, 1, args); err != nil {
+		return nil, err
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		return typ.Elem(), nil
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String && typ.Key().Kind() != reflect.Interface {
+			return nil, nil
+		}
+		return typ.Elem(), nil
+	}
+	return methodResult(m), nil
+}
+
+// checkArgs mirrors compatible, m is the type of the function with recv
+// receiver arguments
+func checkArgs(m reflect.Type, recv int, args []reflect.Type) error {
+	numIn := m.NumIn() - recv
+	variadic := m.IsVariadic()
+	if variadic && len(args) < numIn-1 || !variadic && len(args) != numIn {
+		return errors.New("incompatible number of arguments")
+	}
+	for i, arg := range args {
+		var argType reflect.Type
+		if variadic && i >= numIn-1 {
+			argType = m.In(m.NumIn() - 1).Elem()
+		} else {
+			argType = m.In(i + recv)
+		}
+		if !convertibleType(arg, argType) {
+			return fmt.Errorf("arg %d: %s %s -> %s", i, "not assignable", wrappedType(arg), argType)
+		}
+	}
+	return nil
+}
+
+// methodResult returns type of the first result of the method, nil if it has
+// none
+func methodResult(m reflect.Type) reflect.Type {
+	if m.NumOut() == 0 {
+		return nil
+	}
+	return m.Out(0)
+}
+
+func typeKind(typ reflect.Type) string {
+	w := wrappedType(typ)
+	if w.Implements(reflect.TypeOf((*Kinder)(nil)).Elem()) {
+		return reflect.Zero(w).Interface().(Kinder).kind()
+	}
+	return typ.String()
+}
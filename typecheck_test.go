@@ -0,0 +1,117 @@
+package govtl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tcAddress struct {
+	City string
+}
+
+type tcUser struct {
+	Name    string
+	Address *tcAddress
+	Tags    []string
+	Attrs   map[string]int
+	Friends []*tcUser
+	Meta    interface{}
+	secret  string
+}
+
+func (u *tcUser) GetTitle() string             { return "title" }
+func (u *tcUser) IsAdmin() bool                { return false }
+func (u *tcUser) Greet(greeting string) string { return greeting + u.Name }
+func (u *tcUser) Sum(n ...int) int             { return len(n) }
+func (u *tcUser) Move(to []tcAddress) int      { return len(to) }
+
+type tcSettings struct{}
+
+func (tcSettings) Get(key string) *tcAddress { return nil }
+
+type tcData struct {
+	User     *tcUser
+	Settings tcSettings
+	Count    int
+}
+
+func TestCheckType(t *testing.T) {
+	tests := []struct {
+		name   string
+		tmpl   string
+		expect []string
+	}{
+		{"fields and methods",
+			`$user.name $user.address.city $user.title $user.getTitle() $user.admin $user.isAdmin() $user.greet('hi') $count`,
+			nil},
+		{"get fallback", `$settings.home.city $settings.get('x').city`, nil},
+		{"go collections", `$user.tags[0].length() $user.attrs.k $user.friends[1].name $user.tags.size()`, nil},
+		{"variadic", `$user.sum() $user.sum(1, 2, 3)`, nil},
+		{"unknown is not checked", `$user.meta.anything.goes()`, nil},
+		{"misspelled property", "\n  $user.nmae", []string{"cannot get property nmae of *govtl.tcUser value at line 2, column 9"}},
+		{"unexported field", `$user.secret`, []string{"cannot get property secret of *govtl.tcUser value at line 1, column 7"}},
+		{"nested", `$user.address.town`, []string{"cannot get property town of *govtl.tcAddress value at line 1, column 15"}},
+		{"undefined var", `$usr.name`, []string{"undefined var $usr at line 1, column 1"}},
+		{"arity", `$user.greet()`, []string{"incompatible number of arguments at line 1, column 7"}},
+		{"argument type", `$user.greet($user.address)`, []string{"arg 0: not assignable *govtl.tcAddress -> string at line 1, column 7"}},
+		{"variadic argument type", `$user.sum(1, 'a')`, []string{"arg 1: not assignable govtl.Str -> int at line 1, column 7"}},
+		{"converted arguments", `$user.move([{'city': 'x'}]) $user.move($user.friends[0].address) $user.sum(1.0)`, []string{
+			"arg 0: not assignable *govtl.tcAddress -> []govtl.tcAddress at line 1, column 35",
+		}},
+		{"go collection arguments", `$user.greet($user.tags) $user.move($user.tags)`, []string{
+			"arg 0: not assignable *govtl.Slice -> string at line 1, column 7",
+			"arg 0: not assignable *govtl.Slice -> []govtl.tcAddress at line 1, column 31",
+		}},
+		{"unknown method", `$user.fly()`, []string{"cannot call fly on *govtl.tcUser value at line 1, column 7"}},
+		{"method of string", `$user.name.length() $user.name.fly()`, []string{"cannot call fly on string value at line 1, column 32"}},
+		{"all errors", `$user.a $user.b`, []string{
+			"cannot get property a of *govtl.tcUser value at line 1, column 7",
+			"cannot get property b of *govtl.tcUser value at line 1, column 15",
+		}},
+		{"set", `#set($a = $user.address)$a.city $a.street #set($s = "x$a.zip")$s.length()`, []string{
+			"cannot get property street of *govtl.tcAddress value at line 1, column 36",
+			"cannot get property zip of *govtl.tcAddress value at line 1, column 58",
+		}},
+		{"foreach", `#foreach($f in $user.friends)$f.name $f.nick $foreach.count#end#foreach($i in [1..3])$i.fly()#end`, []string{
+			"cannot get property nick of *govtl.tcUser value at line 1, column 41",
+			"cannot call fly on int64 value at line 1, column 89",
+		}},
+		{"iterate over struct", `#foreach($a in $user.address)#end`, []string{"cannot iterate over *govtl.tcAddress at line 1, column 1"}},
+		{"conditions and alternatives", `#if($missing && $gone.x)#end${missing|'x'}`, nil},
+		{"macro arguments", `#macro(m $x)$x.anything $caller#end#m($user.nmae)`, []string{
+			"cannot get property nmae of *govtl.tcUser value at line 1, column 45",
+		}},
+		{"method arguments", `$user.greet($user.nmae)`, []string{"cannot get property nmae of *govtl.tcUser value at line 1, column 19"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := Parse(test.tmpl, "", "")
+			require.NoError(t, err)
+			err = tmpl.CheckType(reflect.TypeOf(tcData{}))
+			if test.expect == nil {
+				assert.NoError(t, err)
+				return
+			}
+			require.IsType(t, TypeErrors{}, err)
+			var msgs []string
+			for _, e := range err.(TypeErrors) {
+				msgs = append(msgs, e.Error())
+			}
+			assert.Equal(t, test.expect, msgs)
+		})
+	}
+}
+
+func TestCheckTypes(t *testing.T) {
+	tmpl, err := ParseName("user.vm", MapLoader{"user.vm": `$user.name $n.fly() $m.key.city $other`}, "")
+	require.NoError(t, err)
+	err = tmpl.CheckTypes(map[string]reflect.Type{
+		"user": reflect.TypeOf(&tcUser{}),
+		"n":    reflect.TypeOf(0),
+		"m":    reflect.TypeOf(map[string]tcAddress{}),
+	})
+	assert.EqualError(t, err, "user.vm: cannot call fly on int value at line 1, column 15\nuser.vm: undefined var $other at line 1, column 33")
+}
@@ -1,6 +1,7 @@
 package govtl
 
 import (
+	"container/list"
 	"errors"
 	"fmt"
 	"math"
@@ -21,18 +22,30 @@ var errMapExpected = errors.New("map expected")
 
 type Map struct {
 	m interface{}
+	// order of insertion of the keys, nil if keys are iterated in sorted
+	// order
+	order *keyOrder
+	// mod counts additions and removals of keys for fail-fast iteration
+	mod int
+}
+
+// NewOrderedMap returns an empty map which preserves insertion order of the
+// keys, like LinkedHashMap in Velocity
+func NewOrderedMap() *Map {
+	return &Map{m: map[string]interface{}{}, order: newKeyOrder()}
 }
 
 func (m *Map) kind() string { return "map" }
 
 func (m *Map) Clear() {
 	m.m = reflect.MakeMap(reflect.TypeOf(m.m)).Interface()
+	m.order.clear()
+	m.mod++
 }
 
 func (m *Map) ContainsKey(key interface{}) bool {
-	k := reflect.ValueOf(key)
 	mM := reflect.ValueOf(m.m)
-	k, err := convertType(k, mM.Type().Key())
+	k, err := m.key(key)
 	if err != nil {
 		return false
 	}
@@ -70,7 +83,11 @@ func (m *Map) Equals(v interface{}) (bool, error) {
 	}
 	iter := mM.MapRange()
 	for iter.Next() {
-		vV := vM.MapIndex(iter.Key())
+		k, err := convertKey(iter.Key(), vM.Type().Key())
+		if err != nil {
+			return false, nil
+		}
+		vV := vM.MapIndex(k)
 		if vV == Nil || !rTypeConvEQ(vV, iter.Value()) {
 			return false, nil
 		}
@@ -84,14 +101,9 @@ func (m *Map) Get(key interface{}) interface{} {
 
 func (m *Map) GetOrDefault(key interface{}, deflt interface{}) interface{} {
 	mM := reflect.ValueOf(m.m)
-	k := reflect.ValueOf(key)
-	switch {
-	case k.IsValid() && k.Type().AssignableTo(mM.Type().Key()):
-		k = k.Convert(mM.Type().Key())
-	case k.IsValid() && mM.Type().Key() != k.Type() && mM.Type().Key().Kind() == reflect.String:
-		k = reflect.ValueOf(fmt.Sprint(key))
-	case k == Nil:
-		k = reflect.Zero(mM.Type().Key())
+	k, err := m.key(key)
+	if err != nil {
+		return deflt
 	}
 	v := mM.MapIndex(k)
 	if v == Nil {
@@ -100,6 +112,19 @@ func (m *Map) GetOrDefault(key interface{}, deflt interface{}) interface{} {
 	return v.Interface()
 }
 
+// key converts key to the key type of the map, in maps with string keys
+// numbers and booleans are converted to their printed value. It is used
+// both for lookups and for updates, so they find the same entries
+func (m *Map) key(key interface{}) (reflect.Value, error) {
+	keyT := reflect.TypeOf(m.m).Key()
+	k, err := convertKey(reflect.ValueOf(key), keyT)
+	if kv := reflect.ValueOf(key); err != nil && keyT.Kind() == reflect.String &&
+		(isNumber(kv) || kv.Kind() == reflect.Bool) {
+		return reflect.ValueOf(fmt.Sprint(key)).Convert(keyT), nil
+	}
+	return k, err
+}
+
 func (m *Map) IsEmpty() bool {
 	return reflect.ValueOf(m.m).Len() == 0
 }
@@ -110,14 +135,11 @@ func (m *Map) KeySet() *KeyView {
 
 func (m *Map) Put(key, value interface{}) (interface{}, error) {
 	mM := reflect.ValueOf(m.m)
-	kk := reflect.ValueOf(key)
 	vv := reflect.ValueOf(value)
 
 	elemT := mM.Type().Elem()
-	keyT := mM.Type().Key()
 
-	var err error
-	kk, err = convertType(kk, keyT)
+	kk, err := m.key(key)
 	if err != nil {
 		return nil, fmt.Errorf("cannot convert key %w", err)
 	}
@@ -130,6 +152,7 @@ func (m *Map) Put(key, value interface{}) (interface{}, error) {
 	was := mM.MapIndex(kk)
 	mM.SetMapIndex(kk, vv)
 	if was == Nil {
+		m.added(kk)
 		return nil, nil
 	}
 	return was.Interface(), nil
@@ -142,19 +165,20 @@ func (m *Map) PutAll(v interface{}) error {
 	}
 	mM, vM := reflect.ValueOf(m.m), reflect.ValueOf(vv.m)
 	elemT := mM.Type().Elem()
-	keyT := mM.Type().Key()
 
-	iter := vM.MapRange()
-	for iter.Next() {
-		kk, err := convertType(iter.Key(), keyT)
+	for _, k := range vv.keys() {
+		kk, err := m.key(k.Interface())
 		if err != nil {
 			return fmt.Errorf("cannot convert key %w", err)
 		}
 
-		vv, err := convertType(iter.Value(), elemT)
+		vv, err := convertType(vM.MapIndex(k), elemT)
 		if err != nil {
 			return fmt.Errorf("cannot convert value %w", err)
 		}
+		if mM.MapIndex(kk) == Nil {
+			m.added(kk)
+		}
 		mM.SetMapIndex(kk, vv)
 	}
 	return nil
@@ -170,14 +194,17 @@ func (m *Map) PutIfAbsent(key interface{}, value interface{}) interface{} {
 
 func (m *Map) Remove(key interface{}) (interface{}, error) {
 	mM := reflect.ValueOf(m.m)
-	keyT := mM.Type().Key()
-	kk, err := convertType(reflect.ValueOf(key), keyT)
+	kk, err := m.key(key)
 	if err != nil {
 		return nil, fmt.Errorf("cannot convert key %w", err)
 	}
-	v := m.Get(key)
+	v := mM.MapIndex(kk)
+	if v == Nil {
+		return nil, nil
+	}
 	mM.SetMapIndex(kk, Nil)
-	return v, nil
+	m.removed(kk)
+	return v.Interface(), nil
 }
 
 func (m *Map) Replace(key interface{}, val interface{}) interface{} {
@@ -197,6 +224,144 @@ func (m *Map) Values() *ValView {
 	return &ValView{m: m}
 }
 
+func (m *Map) modCount() int { return m.mod }
+
+func (m *Map) added(k reflect.Value) {
+	m.order.add(k)
+	m.mod++
+}
+
+func (m *Map) removed(k reflect.Value) {
+	m.order.remove(k)
+	m.mod++
+}
+
+// keys returns keys of the map in iteration order
+func (m *Map) keys() []reflect.Value {
+	if m.order != nil {
+		return m.order.keys()
+	}
+	keys := reflect.ValueOf(m.m).MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keyLess(keys[i], keys[j]) })
+	return keys
+}
+
+// keyLess orders map keys: nil goes first, then numbers, booleans, strings
+// and other values ordered by type and printed value. Numbers of different
+// types are compared by value, equal ones are ordered as signed, unsigned
+// and then floating point
+func keyLess(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+	ra, rb := keyRank(a), keyRank(b)
+	if ra != rb {
+		return ra < rb
+	}
+	switch ra {
+	case 1:
+		if less, _ := lt(a, b); less {
+			return true
+		}
+		if less, _ := lt(b, a); less {
+			return false
+		}
+		return basicKind(a) < basicKind(b)
+	case 2:
+		// false < true
+		return !a.Bool() && b.Bool()
+	case 3:
+		return a.String() < b.String()
+	case 4:
+		if a.Type() != b.Type() {
+			return a.Type().String() < b.Type().String()
+		}
+		return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+	}
+	return false
+}
+
+func keyRank(v reflect.Value) int {
+	switch {
+	case !v.IsValid():
+		return 0
+	case isNumber(v):
+		return 1
+	case v.Kind() == reflect.Bool:
+		return 2
+	case v.Kind() == reflect.String:
+		return 3
+	}
+	return 4
+}
+
+// convertKey converts k to the key type of the map. Template strings are
+// stored as plain strings in maps with interface keys, so they match keys
+// set from Go
+func convertKey(k reflect.Value, keyT reflect.Type) (reflect.Value, error) {
+	if k.Kind() == reflect.Interface {
+		k = k.Elem()
+	}
+	if k.IsValid() && k.Type() == strType && keyT.Kind() == reflect.Interface {
+		k = reflect.ValueOf(k.String())
+	}
+	if k.IsValid() && !k.Type().Comparable() {
+		return Nil, fmt.Errorf("%s which is not comparable", getKind(k))
+	}
+	return convertType(k, keyT)
+}
+
+// keyOrder keeps keys of the map in insertion order, methods of nil
+// keyOrder do nothing
+type keyOrder struct {
+	l *list.List
+	e map[interface{}]*list.Element
+}
+
+func newKeyOrder() *keyOrder {
+	return &keyOrder{l: list.New(), e: make(map[interface{}]*list.Element)}
+}
+
+// add appends k unless it is already present, so replacing the value keeps
+// the position of the key
+func (o *keyOrder) add(k reflect.Value) {
+	if o == nil {
+		return
+	}
+	if _, ok := o.e[k.Interface()]; !ok {
+		o.e[k.Interface()] = o.l.PushBack(k)
+	}
+}
+
+func (o *keyOrder) remove(k reflect.Value) {
+	if o == nil {
+		return
+	}
+	if e, ok := o.e[k.Interface()]; ok {
+		o.l.Remove(e)
+		delete(o.e, k.Interface())
+	}
+}
+
+func (o *keyOrder) clear() {
+	if o == nil {
+		return
+	}
+	o.l.Init()
+	o.e = make(map[interface{}]*list.Element)
+}
+
+func (o *keyOrder) keys() []reflect.Value {
+	keys := make([]reflect.Value, 0, o.l.Len())
+	for e := o.l.Front(); e != nil; e = e.Next() {
+		keys = append(keys, e.Value.(reflect.Value))
+	}
+	return keys
+}
+
 type MapEntry struct {
 	k interface{}
 	v interface{}
@@ -259,12 +424,15 @@ func (view *KeyView) Iterator() Iterator {
 
 func (v *KeyView) kind() string { return "keyView" }
 
-func (view *KeyView) Remove(k interface{}) bool {
+func (view *KeyView) Remove(k interface{}) (bool, error) {
 	ok := view.m.ContainsKey(k)
-	if ok {
-		view.m.Remove(k)
+	if !ok {
+		return false, nil
+	}
+	if _, err := view.m.Remove(k); err != nil {
+		return false, err
 	}
-	return ok
+	return true, nil
 }
 
 func (view *KeyView) RemoveAll(v interface{}) (bool, error) {
@@ -311,17 +479,14 @@ func (view *ValView) kind() string { return "valView" }
 
 func (view *ValView) Remove(val interface{}) bool {
 	mM := reflect.ValueOf(view.m.m)
-	var found bool
-	iter := mM.MapRange()
-	for iter.Next() {
-		v := iter.Value()
-		if rTypeConvEQ(v, reflect.ValueOf(val)) {
-			mM.SetMapIndex(iter.Key(), Nil)
-			found = true
-			break
+	for _, k := range view.m.keys() {
+		if rTypeConvEQ(mM.MapIndex(k), reflect.ValueOf(val)) {
+			mM.SetMapIndex(k, Nil)
+			view.m.removed(k)
+			return true
 		}
 	}
-	return found
+	return false
 }
 
 func (view *ValView) RemoveAll(v interface{}) (bool, error) {
@@ -363,7 +528,11 @@ func (view *EntryView) Contains(val interface{}) bool {
 func (view *EntryView) Iterator() Iterator {
 	return NewMapIterator(view.m,
 		func(m, k reflect.Value) interface{} {
-			return &MapEntry{k: k.Interface(), v: m.MapIndex(k).Interface(), m: view.m}
+			e := &MapEntry{k: k.Interface(), m: view.m}
+			if v := m.MapIndex(k); v != Nil {
+				e.v = v.Interface()
+			}
+			return e
 		})
 }
 
@@ -374,6 +543,7 @@ func (view *EntryView) Remove(val *MapEntry) bool {
 	v := mM.MapIndex(reflect.ValueOf(val.k))
 	if v != Nil && rTypeConvEQ(v, reflect.ValueOf(val.v)) {
 		mM.SetMapIndex(reflect.ValueOf(val.k), Nil)
+		view.m.removed(reflect.ValueOf(val.k))
 		return true
 	}
 	return false
@@ -406,6 +576,9 @@ var errArrayExpected = errors.New("array expected")
 
 type Slice struct {
 	s interface{}
+	// mod counts additions and removals of elements for fail-fast
+	// iteration
+	mod int
 }
 
 func (s *Slice) checkNil() {
@@ -424,6 +597,7 @@ func (s *Slice) Add(v interface{}) (bool, error) {
 		return false, fmt.Errorf("cannot convert argument %w", err)
 	}
 	s.s = reflect.Append(sS, vv).Interface()
+	s.mod++
 	return true, nil
 }
 
@@ -451,6 +625,7 @@ func (s *Slice) AddAll(v interface{}) (bool, error) {
 		sS = reflect.Append(sS, nv)
 	}
 	s.s = sS.Interface()
+	s.mod++
 	return true, nil
 }
 
@@ -461,6 +636,7 @@ func (s *Slice) Clear() error {
 	} else {
 		s.s = []interface{}(nil)
 	}
+	s.mod++
 	return nil
 }
 
@@ -521,6 +697,7 @@ func (s *Slice) Remove(v interface{}) (bool, error) {
 	for i := 0; i < sS.Len(); i++ {
 		if rTypeConvEQ(sS.Index(i), reflect.ValueOf(v)) {
 			s.s = reflect.AppendSlice(sS.Slice(0, i), sS.Slice(i+1, sS.Len())).Interface()
+			s.mod++
 			return true, nil
 		}
 	}
@@ -534,6 +711,7 @@ func (s *Slice) removeAt(i int) error {
 		return fmt.Errorf("index out of range %d with length %d", i, sS.Len())
 	}
 	s.s = reflect.AppendSlice(sS.Slice(0, i), sS.Slice(i+1, sS.Len())).Interface()
+	s.mod++
 	return nil
 }
 
@@ -575,6 +753,8 @@ func (s *Slice) Set(i int, v interface{}) (interface{}, error) {
 	return r, nil
 }
 
+func (s *Slice) modCount() int { return s.mod }
+
 func (s *Slice) Size() int {
 	s.checkNil()
 	return reflect.ValueOf(s.s).Len()
@@ -585,22 +765,43 @@ func (s *Slice) ToArray() (*Slice, error) {
 	sS := reflect.ValueOf(s.s)
 	ss := reflect.MakeSlice(sS.Type(), sS.Len(), sS.Len())
 	reflect.Copy(ss, sS)
-	return &Slice{ss.Interface()}, nil
+	return &Slice{s: ss.Interface()}, nil
 }
 
 var errIteratorInvalidState = errors.New("next hasn't yet been called on iterator")
 var errIteratorOutOfRange = errors.New("iterator out of range")
 
+// ErrConcurrentModification is returned by fail-fast iteration when the
+// collection is modified during #foreach not through the iterator
+var ErrConcurrentModification = errors.New("concurrent modification: collection was changed during iteration")
+
 type Iterator interface {
 	Next() (interface{}, error)
 	HasNext() bool
 	Remove() error
 }
 
+// failFaster is implemented by iterators which could detect modifications
+// of their collection
+type failFaster interface {
+	failFast()
+}
+
+func modCount(c interface{}) int {
+	if m, ok := c.(interface{ modCount() int }); ok {
+		return m.modCount()
+	}
+	return 0
+}
+
 type CollectionIterator struct {
 	s    Collection
 	i    int
 	last int
+	// when check is set, modification count and size of the collection
+	// must match mod and size
+	check     bool
+	mod, size int
 }
 
 func NewIterator(v interface{}) Iterator {
@@ -617,7 +818,15 @@ func NewIterator(v interface{}) Iterator {
 
 func (it *CollectionIterator) kind() string { return "iterator" }
 
+func (it *CollectionIterator) failFast() {
+	it.check = true
+	it.mod, it.size = modCount(it.s), it.s.Size()
+}
+
 func (it *CollectionIterator) Next() (interface{}, error) {
+	if it.check && (modCount(it.s) != it.mod || it.s.Size() != it.size) {
+		return nil, ErrConcurrentModification
+	}
 	if it.i >= it.s.Size() {
 		return nil, errIteratorOutOfRange
 	}
@@ -639,41 +848,38 @@ func (it *CollectionIterator) Remove() error {
 	}
 	it.i--
 	it.last = 0
+	if it.check {
+		it.failFast()
+	}
 	return nil
 }
 
 type MapIterator struct {
+	m       *Map
 	mM      reflect.Value
 	mapper  func(m, k reflect.Value) interface{}
 	k       []reflect.Value
 	i, last int
+	// when check is set, modification count and size of the map must
+	// match mod and size
+	check     bool
+	mod, size int
 }
 
 func NewMapIterator(m *Map, mapper func(m, k reflect.Value) interface{}) *MapIterator {
-	mM := reflect.ValueOf(m.m)
-	keys := mM.MapKeys()
-	kind := basicKind(reflect.Zero(mM.Type().Key()))
-	sort.Slice(keys, func(i, j int) bool {
-		switch kind {
-		case reflect.String:
-			return keys[i].String() < keys[j].String()
-		case reflect.Int64:
-			return keys[i].Int() < keys[j].Int()
-		case reflect.Uint64:
-			return keys[i].Uint() < keys[j].Uint()
-		case reflect.Float64:
-			return keys[i].Float() < keys[j].Float()
-		case reflect.Bool:
-			// false < true
-			return keys[j].Bool() && !keys[i].Bool()
-		default:
-			return true
-		}
-	})
-	return &MapIterator{mM: mM, k: keys, mapper: mapper}
+	return &MapIterator{m: m, mM: reflect.ValueOf(m.m), k: m.keys(), mapper: mapper}
 }
+
+func (it *MapIterator) failFast() {
+	it.check = true
+	it.mod, it.size = it.m.modCount(), it.m.Size()
+}
+
 func (it *MapIterator) HasNext() bool { return it.i < len(it.k) }
 func (it *MapIterator) Next() (interface{}, error) {
+	if it.check && (it.m.modCount() != it.mod || it.m.Size() != it.size) {
+		return nil, ErrConcurrentModification
+	}
 	if it.i >= len(it.k) {
 		return nil, errIteratorOutOfRange
 	}
@@ -687,6 +893,12 @@ func (it *MapIterator) Remove() error {
 	}
 	it.last = 0
 	it.mM.SetMapIndex(it.k[it.i-1], Nil)
+	if it.m != nil {
+		it.m.removed(it.k[it.i-1])
+	}
+	if it.check {
+		it.failFast()
+	}
 	return nil
 }
 
@@ -1022,7 +1234,8 @@ func convertType(v reflect.Value, t reflect.Type) (reflect.Value, error) {
 		}
 	case v.Type().AssignableTo(t):
 		return v, nil
-	case v.Type().ConvertibleTo(t):
+	case v.Type().ConvertibleTo(t) && !(isInt(v) && t.Kind() == reflect.String):
+		// Go converts integers to strings as runes
 		return v.Convert(t), nil
 	}
 	return Nil, fmt.Errorf("%s to %s", getKind(v), getKind(t))
@@ -1039,7 +1252,7 @@ func toArray(it Iterator, s reflect.Value) (*Slice, error) {
 		s.Index(i).Set(val)
 		i++
 	}
-	return &Slice{s.Interface()}, nil
+	return &Slice{s: s.Interface()}, nil
 }
 
 // iTypeConvEQ compares interface values trying to convert them to the same underlying type. See
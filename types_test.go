@@ -144,10 +144,10 @@ func TestMap_EntrySet(t *testing.T) {
 	}{
 		{"works on empty map",
 			fields{emptyMap()},
-			&Map{emptyMap()}},
+			&Map{m: emptyMap()}},
 		{"returns entryset sorted",
 			fields{m},
-			&Map{m}},
+			&Map{m: m}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -181,37 +181,37 @@ func TestMap_Equals(t *testing.T) {
 			assert.Error},
 		{"empty map",
 			fields{emptyMap()},
-			args{&Map{emptyMap()}},
+			args{&Map{m: emptyMap()}},
 			true,
 			assert.NoError},
 		{"different length",
 			fields{emptyMap()},
-			args{&Map{map[string]interface{}{"a": 1}}},
+			args{&Map{m: map[string]interface{}{"a": 1}}},
 			false,
 			assert.NoError},
 		{"different length the other way",
 			fields{map[string]interface{}{"a": 1}},
-			args{&Map{emptyMap()}},
+			args{&Map{m: emptyMap()}},
 			false,
 			assert.NoError},
 		{"different comparable content",
 			fields{map[string]interface{}{"a": 1, "and": "string"}},
-			args{&Map{map[string]interface{}{"a": 1, "and": "other"}}},
+			args{&Map{m: map[string]interface{}{"a": 1, "and": "other"}}},
 			false,
 			assert.NoError},
 		{"different non-comparable content",
 			fields{map[string]interface{}{"a": 1, "and": []string{"string"}}},
-			args{&Map{map[string]interface{}{"a": 1, "and": []string{"other"}}}},
+			args{&Map{m: map[string]interface{}{"a": 1, "and": []string{"other"}}}},
 			false,
 			assert.NoError},
 		{"same comparable content",
 			fields{map[string]interface{}{"a": 1, "and": "string"}},
-			args{&Map{map[string]interface{}{"a": 1, "and": "string"}}},
+			args{&Map{m: map[string]interface{}{"a": 1, "and": "string"}}},
 			true,
 			assert.NoError},
 		{"same non-comparable content",
-			fields{map[string]interface{}{"a": &Map{map[string]interface{}{"inner": "map"}}, "and": &Slice{[]string{"with", "items"}}}},
-			args{&Map{map[string]interface{}{"a": &Map{map[string]interface{}{"inner": "map"}}, "and": &Slice{[]string{"with", "items"}}}}},
+			fields{map[string]interface{}{"a": &Map{m: map[string]interface{}{"inner": "map"}}, "and": &Slice{s: []string{"with", "items"}}}},
+			args{&Map{m: map[string]interface{}{"a": &Map{m: map[string]interface{}{"inner": "map"}}, "and": &Slice{s: []string{"with", "items"}}}}},
 			true,
 			assert.NoError},
 	}
@@ -359,10 +359,10 @@ func TestMap_KeySet(t *testing.T) {
 	}{
 		{"works on empty map",
 			fields{empty},
-			&KeyView{&Map{empty}}},
+			&KeyView{&Map{m: empty}}},
 		{"returns keyset sorted",
 			fields{m},
-			&KeyView{&Map{m}}},
+			&KeyView{&Map{m: m}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -409,10 +409,18 @@ func TestMap_Put(t *testing.T) {
 			fields{m},
 			args{"other", "key"},
 			nil, "key", assert.NoError, ""},
-		{"wrong key type",
+		{"number key is printed",
 			fields{m},
 			args{1.0, "key"},
-			nil, nil, assert.Error, "cannot convert key float64 to string"},
+			nil, "key", assert.NoError, ""},
+		{"and is found by string",
+			fields{m},
+			args{"1", "str"},
+			"key", "str", assert.NoError, ""},
+		{"wrong key type",
+			fields{m},
+			args{&Slice{s: []int{1}}, "key"},
+			nil, nil, assert.Error, "cannot convert key slice to string"},
 		{"wrong value type",
 			fields{msi},
 			args{"other", "string"},
@@ -456,29 +464,34 @@ func TestMap_PutAll(t *testing.T) {
 			assert.Error},
 		{"wrong key type",
 			fields{m},
-			args{&Map{map[float32]interface{}{1.0: "b", 2.0: 2}}},
+			args{&Map{m: map[*Slice]interface{}{{}: "b"}}},
 			map[string]interface{}{},
 			assert.Error},
 		{"wrong value type",
 			fields{msi},
-			args{&Map{map[string]string{"a": "string", "c": "other"}}},
+			args{&Map{m: map[string]string{"a": "string", "c": "other"}}},
 			map[string]int{},
 			assert.Error},
 		{"works on empty map",
 			fields{m},
-			args{&Map{map[string]interface{}{"a": "b", "c": 2}}},
+			args{&Map{m: map[string]interface{}{"a": "b", "c": 2}}},
 			map[string]interface{}{"a": "b", "c": 2},
 			assert.NoError},
 		{"works on empty map as argument",
 			fields{m},
-			args{&Map{emptyMap()}},
+			args{&Map{m: emptyMap()}},
 			map[string]interface{}{"a": "b", "c": 2},
 			assert.NoError},
 		{"adds and replaces value",
 			fields{m},
-			args{&Map{map[string]interface{}{"c": "d", "e": []int{1}, "n": nil}}},
+			args{&Map{m: map[string]interface{}{"c": "d", "e": []int{1}, "n": nil}}},
 			map[string]interface{}{"a": "b", "c": "d", "e": []int{1}, "n": nil},
 			assert.NoError},
+		{"prints number keys",
+			fields{m},
+			args{&Map{m: map[float32]interface{}{1: "f", 2.5: 2}}},
+			map[string]interface{}{"a": "b", "c": "d", "e": []int{1}, "n": nil, "1": "f", "2.5": 2},
+			assert.NoError},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -563,7 +576,7 @@ func TestMap_Remove(t *testing.T) {
 			map[string]interface{}{}},
 		{"wrong type for key",
 			fields{map[string]interface{}{"k": 1}},
-			args{&Slice{[]string{"k"}}},
+			args{&Slice{s: []string{"k"}}},
 			nil,
 			assert.Error,
 			map[string]interface{}{"k": 1}},
@@ -680,10 +693,10 @@ func TestMap_Values(t *testing.T) {
 	}{
 		{"works on empty map",
 			fields{empty},
-			&ValView{&Map{map[string]interface{}{}}}},
+			&ValView{&Map{m: map[string]interface{}{}}}},
 		{"returns values in order of sorted keys",
 			fields{m},
-			&ValView{&Map{m}}},
+			&ValView{&Map{m: m}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -704,7 +717,7 @@ func TestMapEntry_Equals(t *testing.T) {
 	type args struct {
 		entry *MapEntry
 	}
-	empty := &Map{emptyMap()}
+	empty := &Map{m: emptyMap()}
 	tests := []struct {
 		name   string
 		fields fields
@@ -725,7 +738,7 @@ func TestMapEntry_Equals(t *testing.T) {
 			false},
 		{"unequal map entries from different maps",
 			fields{"K", 1, empty},
-			args{&MapEntry{"k", 1, &Map{map[string]interface{}{"k": 1}}}},
+			args{&MapEntry{"k", 1, &Map{m: map[string]interface{}{"k": 1}}}},
 			false},
 	}
 	for _, tt := range tests {
@@ -803,8 +816,8 @@ func TestMapEntry_SetValue(t *testing.T) {
 	type args struct {
 		val interface{}
 	}
-	m := &Map{map[string]interface{}{"k": 1, "other": "value"}}
-	msi := &Map{map[string]int{"a": 1}}
+	m := &Map{m: map[string]interface{}{"k": 1, "other": "value"}}
+	msi := &Map{m: map[string]int{"a": 1}}
 	tests := []struct {
 		name      string
 		fields    fields
@@ -927,9 +940,9 @@ func TestView_Clear(t *testing.T) {
 		fields fields
 	}{
 		{"works with empty",
-			fields{&Map{emptyMap()}}},
+			fields{&Map{m: emptyMap()}}},
 		{"clears full",
-			fields{&Map{map[string]interface{}{"a": 1, "b": "two"}}}},
+			fields{&Map{m: map[string]interface{}{"a": 1, "b": "two"}}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -957,27 +970,27 @@ func TestKeyView_Contains(t *testing.T) {
 		want   bool
 	}{
 		{"works with empty",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			args{"key"},
 			false},
 		{"does not contain non-existant",
-			fields{&Map{map[string]interface{}{"k": 1}}},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
 			args{"key"},
 			false},
 		{"does not contain int type",
-			fields{&Map{map[string]interface{}{"k": 1, "key": "value"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "key": "value"}}},
 			args{1},
 			false},
 		{"does not contain pointer type",
-			fields{&Map{map[string]interface{}{"k": 1, "key": "value"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "key": "value"}}},
 			args{&k},
 			false},
 		{"contains wrapped type",
-			fields{&Map{map[string]interface{}{"k": 1, "key": "value"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "key": "value"}}},
 			args{Str("k")},
 			true},
 		{"contains existing",
-			fields{&Map{map[string]interface{}{"k": 1, "key": "value"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "key": "value"}}},
 			args{"key"},
 			true},
 	}
@@ -1001,13 +1014,13 @@ func TestKeyView_Iterator(t *testing.T) {
 		want   []interface{}
 	}{
 		{"works with empty",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			nil},
 		{"single key",
-			fields{&Map{map[string]interface{}{"k": 1}}},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
 			[]interface{}{"k"}},
 		{"multiple keys in order",
-			fields{&Map{map[string]interface{}{"b": 1, "a": "value"}}},
+			fields{&Map{m: map[string]interface{}{"b": 1, "a": "value"}}},
 			[]interface{}{"a", "b"}},
 	}
 	for _, tt := range tests {
@@ -1041,17 +1054,17 @@ func TestKeyView_Remove(t *testing.T) {
 		wantM  map[string]interface{}
 	}{
 		{"works with empty",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			args{"key"},
 			false,
 			emptyMap()},
 		{"does nothing for non-existant",
-			fields{&Map{map[string]interface{}{"k": 1}}},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
 			args{"key"},
 			false,
 			map[string]interface{}{"k": 1}},
 		{"removes from map",
-			fields{&Map{map[string]interface{}{"k": 1, "key": "value"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "key": "value"}}},
 			args{"key"},
 			true,
 			map[string]interface{}{"k": 1}},
@@ -1061,7 +1074,9 @@ func TestKeyView_Remove(t *testing.T) {
 			view := &KeyView{
 				m: tt.fields.m,
 			}
-			assert.Equal(t, tt.want, view.Remove(tt.args.k))
+			got, err := view.Remove(tt.args.k)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
 			assert.Equal(t, tt.wantM, view.m.m)
 		})
 	}
@@ -1083,31 +1098,31 @@ func TestKeyView_RemoveAll(t *testing.T) {
 		wantM     interface{}
 	}{
 		{"wrong argument",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			args{&Map{}},
 			false,
 			assert.Error,
 			emptyMap()},
 		{"works with empty",
-			fields{&Map{emptyMap()}},
-			args{&Slice{[]interface{}{"key", 1}}},
+			fields{&Map{m: emptyMap()}},
+			args{&Slice{s: []interface{}{"key", 1}}},
 			false,
 			assert.NoError,
 			emptyMap()},
 		{"does nothing for non-existant",
-			fields{&Map{map[string]interface{}{"k": 1}}},
-			args{&Slice{[]interface{}{"key"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
+			args{&Slice{s: []interface{}{"key"}}},
 			false,
 			assert.NoError,
 			map[string]interface{}{"k": 1}},
 		{"removes all found from slice and map",
-			fields{&Map{map[string]interface{}{"k": 1, "key": "value"}}},
-			args{&Slice{[]interface{}{"key", 1}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "key": "value"}}},
+			args{&Slice{s: []interface{}{"key", 1}}},
 			true,
 			assert.NoError,
 			map[string]interface{}{"k": 1}},
 		{"removes range from slice and map",
-			fields{&Map{map[int]interface{}{1: 1, 5: "value", 3: 1.0, 2: []int{1}}}},
+			fields{&Map{m: map[int]interface{}{1: 1, 5: "value", 3: 1.0, 2: []int{1}}}},
 			args{&Range{1, 3, 1}},
 			true,
 			assert.NoError,
@@ -1142,37 +1157,37 @@ func TestKeyView_RetainAll(t *testing.T) {
 		wantM     interface{}
 	}{
 		{"wrong argument",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			args{&Map{}},
 			false,
 			assert.Error,
 			emptyMap()},
 		{"works with empty",
-			fields{&Map{emptyMap()}},
-			args{&Slice{[]interface{}{"key", 1}}},
+			fields{&Map{m: emptyMap()}},
+			args{&Slice{s: []interface{}{"key", 1}}},
 			false,
 			assert.NoError,
 			emptyMap()},
 		{"does nothing for existing",
-			fields{&Map{map[string]interface{}{"k": 1}}},
-			args{&Slice{[]interface{}{"k"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
+			args{&Slice{s: []interface{}{"k"}}},
 			false,
 			assert.NoError,
 			map[string]interface{}{"k": 1}},
 		{"retains only existing",
-			fields{&Map{map[string]interface{}{"k": 1, "and": 2}}},
-			args{&Slice{[]interface{}{"some", "keys"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "and": 2}}},
+			args{&Slice{s: []interface{}{"some", "keys"}}},
 			true,
 			assert.NoError,
 			map[string]interface{}{}},
 		{"removes all not found in other from slice and map",
-			fields{&Map{map[string]interface{}{"k": 1, "key": "value"}}},
-			args{&Slice{[]interface{}{"key", 1}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "key": "value"}}},
+			args{&Slice{s: []interface{}{"key", 1}}},
 			true,
 			assert.NoError,
 			map[string]interface{}{"key": "value"}},
 		{"retains range in slice and map",
-			fields{&Map{map[int]interface{}{1: 1, 5: "value", 3: 1.0, 2: []int{1}}}},
+			fields{&Map{m: map[int]interface{}{1: 1, 5: "value", 3: 1.0, 2: []int{1}}}},
 			args{&Range{1, 3, 1}},
 			true,
 			assert.NoError,
@@ -1202,14 +1217,14 @@ func TestKeyView_ToArray(t *testing.T) {
 		assertion assert.ErrorAssertionFunc
 	}{
 		{"works with empty",
-			fields{&Map{emptyMap()}},
-			&Slice{[]string{}}, assert.NoError},
+			fields{&Map{m: emptyMap()}},
+			&Slice{s: []string{}}, assert.NoError},
 		{"single key",
-			fields{&Map{map[string]interface{}{"k": 1}}},
-			&Slice{[]string{"k"}}, assert.NoError},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
+			&Slice{s: []string{"k"}}, assert.NoError},
 		{"multiple keys in order",
-			fields{&Map{map[string]interface{}{"b": 1, "a": "value"}}},
-			&Slice{[]string{"a", "b"}}, assert.NoError},
+			fields{&Map{m: map[string]interface{}{"b": 1, "a": "value"}}},
+			&Slice{s: []string{"a", "b"}}, assert.NoError},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1237,24 +1252,24 @@ func TestValView_Contains(t *testing.T) {
 		want   bool
 	}{
 		{"does not contain anything in empty map",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			args{"value"},
 			false},
 		{"contains simple value",
-			fields{&Map{map[string]interface{}{"k": "value"}}},
+			fields{&Map{m: map[string]interface{}{"k": "value"}}},
 			args{"value"},
 			true},
 		{"contains slice value",
-			fields{&Map{map[string]interface{}{"k": []string{"value1", "value2"}, "k2": "value1"}}},
-			args{&Slice{[]string{"value1", "value2"}}},
+			fields{&Map{m: map[string]interface{}{"k": []string{"value1", "value2"}, "k2": "value1"}}},
+			args{&Slice{s: []string{"value1", "value2"}}},
 			true},
 		{"slices comparable too",
-			fields{&Map{map[string]interface{}{"k": &Slice{[]string{"value1", "value2"}}, "k2": "value1"}}},
-			args{&Slice{[]string{"value1", "value2"}}},
+			fields{&Map{m: map[string]interface{}{"k": &Slice{s: []string{"value1", "value2"}}, "k2": "value1"}}},
+			args{&Slice{s: []string{"value1", "value2"}}},
 			true},
 		{"does not contain value",
-			fields{&Map{map[string]interface{}{"k": []string{"value1", "value2"}, "k2": "value1"}}},
-			args{&Slice{[]string{"value1"}}},
+			fields{&Map{m: map[string]interface{}{"k": []string{"value1", "value2"}, "k2": "value1"}}},
+			args{&Slice{s: []string{"value1"}}},
 			false},
 	}
 	for _, tt := range tests {
@@ -1277,16 +1292,16 @@ func TestValView_Iterator(t *testing.T) {
 		want   []interface{}
 	}{
 		{"works with empty",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			nil},
 		{"single value",
-			fields{&Map{map[string]interface{}{"k": 1}}},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
 			[]interface{}{1}},
 		{"multiple values in order",
-			fields{&Map{map[string]interface{}{"b": 1, "a": "value", "c": 1, "d": nil}}},
+			fields{&Map{m: map[string]interface{}{"b": 1, "a": "value", "c": 1, "d": nil}}},
 			[]interface{}{"value", 1, 1, nil}},
 		{"typed map",
-			fields{&Map{map[string]int{"b": 1, "a": 2, "c": 1}}},
+			fields{&Map{m: map[string]int{"b": 1, "a": 2, "c": 1}}},
 			[]interface{}{2, 1, 1}},
 	}
 	for _, tt := range tests {
@@ -1317,17 +1332,17 @@ func TestValView_ToArray(t *testing.T) {
 		assertion assert.ErrorAssertionFunc
 	}{
 		{"works with empty",
-			fields{&Map{emptyMap()}},
-			&Slice{[]interface{}{}}, assert.NoError},
+			fields{&Map{m: emptyMap()}},
+			&Slice{s: []interface{}{}}, assert.NoError},
 		{"single value",
-			fields{&Map{map[string]interface{}{"k": 1}}},
-			&Slice{[]interface{}{1}}, assert.NoError},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
+			&Slice{s: []interface{}{1}}, assert.NoError},
 		{"multiple values in order",
-			fields{&Map{map[string]int{"b": 1, "a": 2}}},
-			&Slice{[]int{2, 1}}, assert.NoError},
+			fields{&Map{m: map[string]int{"b": 1, "a": 2}}},
+			&Slice{s: []int{2, 1}}, assert.NoError},
 		{"nil values should be supported",
-			fields{&Map{map[string]interface{}{"b": 1, "a": nil}}},
-			&Slice{[]interface{}{nil, 1}}, assert.NoError},
+			fields{&Map{m: map[string]interface{}{"b": 1, "a": nil}}},
+			&Slice{s: []interface{}{nil, 1}}, assert.NoError},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1356,22 +1371,22 @@ func TestValView_Remove(t *testing.T) {
 		wantM  map[string]interface{}
 	}{
 		{"works with empty",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			args{2},
 			false,
 			emptyMap()},
 		{"does nothing for non-existant",
-			fields{&Map{map[string]interface{}{"k": 1}}},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
 			args{2},
 			false,
 			map[string]interface{}{"k": 1}},
 		{"removes from slice, map, and key slice",
-			fields{&Map{map[string]interface{}{"k": 1, "key": "value"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "key": "value"}}},
 			args{1},
 			true,
 			map[string]interface{}{"key": "value"}},
 		{"removes even non-comparable values",
-			fields{&Map{map[string]interface{}{"k": []int{1}, "key": "value"}}},
+			fields{&Map{m: map[string]interface{}{"k": []int{1}, "key": "value"}}},
 			args{[]int{1}},
 			true,
 			map[string]interface{}{"key": "value"}},
@@ -1403,38 +1418,38 @@ func TestValView_RemoveAll(t *testing.T) {
 		wantM     interface{}
 	}{
 		{"wrong type",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			args{&Map{}},
 			false,
 			assert.Error,
 			emptyMap()},
 		{"works with empty",
-			fields{&Map{emptyMap()}},
-			args{&Slice{[]interface{}{2, "value"}}},
+			fields{&Map{m: emptyMap()}},
+			args{&Slice{s: []interface{}{2, "value"}}},
 			false,
 			assert.NoError,
 			emptyMap()},
 		{"does nothing for non-existant",
-			fields{&Map{map[string]interface{}{"k": 1}}},
-			args{&Slice{[]interface{}{2, "value"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
+			args{&Slice{s: []interface{}{2, "value"}}},
 			false,
 			assert.NoError,
 			map[string]interface{}{"k": 1}},
 		{"removes from slice, map, and key slice",
-			fields{&Map{map[string]interface{}{"k": 1, "key": "value"}}},
-			args{&Slice{[]interface{}{1, "v"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "key": "value"}}},
+			args{&Slice{s: []interface{}{1, "v"}}},
 			true,
 			assert.NoError,
 			map[string]interface{}{"key": "value"}},
 		{"removes given range",
-			fields{&Map{map[int]interface{}{1: 1, 2: 2}}},
+			fields{&Map{m: map[int]interface{}{1: 1, 2: 2}}},
 			args{&Range{2, 5, 1}},
 			true,
 			assert.NoError,
 			map[int]interface{}{1: 1}},
 		{"removes even non-comparable values",
-			fields{&Map{map[string]interface{}{"k": []int{1}, "key": "value"}}},
-			args{&Slice{[]interface{}{[]int{1}, 1}}},
+			fields{&Map{m: map[string]interface{}{"k": []int{1}, "key": "value"}}},
+			args{&Slice{s: []interface{}{[]int{1}, 1}}},
 			true,
 			assert.NoError,
 			map[string]interface{}{"key": "value"}},
@@ -1468,38 +1483,38 @@ func TestValView_RetainAll(t *testing.T) {
 		wantM     interface{}
 	}{
 		{"wrong type",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			args{&Map{}},
 			false,
 			assert.Error,
 			emptyMap()},
 		{"works with empty",
-			fields{&Map{emptyMap()}},
-			args{&Slice{[]interface{}{2, "value"}}},
+			fields{&Map{m: emptyMap()}},
+			args{&Slice{s: []interface{}{2, "value"}}},
 			false,
 			assert.NoError,
 			emptyMap()},
 		{"does nothing for existing",
-			fields{&Map{map[string]interface{}{"k": 1}}},
-			args{&Slice{[]interface{}{1, "value"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
+			args{&Slice{s: []interface{}{1, "value"}}},
 			false,
 			assert.NoError,
 			map[string]interface{}{"k": 1}},
 		{"retains only existing",
-			fields{&Map{map[string]interface{}{"k": 1, "and": 2}}},
-			args{&Slice{[]interface{}{"some", "value"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "and": 2}}},
+			args{&Slice{s: []interface{}{"some", "value"}}},
 			true,
 			assert.NoError,
 			map[string]interface{}{}},
 		{"removes given range",
-			fields{&Map{map[int]interface{}{1: 1, 2: 2}}},
+			fields{&Map{m: map[int]interface{}{1: 1, 2: 2}}},
 			args{&Range{2, 5, 1}},
 			true,
 			assert.NoError,
 			map[int]interface{}{2: 2}},
 		{"retains even non-comparable values",
-			fields{&Map{map[string]interface{}{"k": []int{1}, "key": "value"}}},
-			args{&Slice{[]interface{}{[]int{1}, 1}}},
+			fields{&Map{m: map[string]interface{}{"k": []int{1}, "key": "value"}}},
+			args{&Slice{s: []interface{}{[]int{1}, 1}}},
 			true,
 			assert.NoError,
 			map[string]interface{}{"k": []int{1}}},
@@ -1531,28 +1546,28 @@ func TestEntryView_Contains(t *testing.T) {
 		want   bool
 	}{
 		{"does not contain anything in empty map",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			args{&MapEntry{"key", "value", nil}},
 			false},
 		{"does not contain non-entry",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			args{"string"},
 			false},
 		{"contains simple value",
-			fields{&Map{map[string]interface{}{"k": "value"}}},
+			fields{&Map{m: map[string]interface{}{"k": "value"}}},
 			args{&MapEntry{"k", "value", nil}},
 			true},
 		{"contains slice value",
-			fields{&Map{map[string]interface{}{"k": []string{"value1", "value2"}, "k2": "value1"}}},
-			args{&MapEntry{"k", &Slice{[]string{"value1", "value2"}}, nil}},
+			fields{&Map{m: map[string]interface{}{"k": []string{"value1", "value2"}, "k2": "value1"}}},
+			args{&MapEntry{"k", &Slice{s: []string{"value1", "value2"}}, nil}},
 			true},
 		{"slices comparable too",
-			fields{&Map{map[string]interface{}{"k": &Slice{[]string{"value1", "value2"}}, "k2": "value1"}}},
-			args{&MapEntry{"k", &Slice{[]string{"value1", "value2"}}, nil}},
+			fields{&Map{m: map[string]interface{}{"k": &Slice{s: []string{"value1", "value2"}}, "k2": "value1"}}},
+			args{&MapEntry{"k", &Slice{s: []string{"value1", "value2"}}, nil}},
 			true},
 		{"does not contain value",
-			fields{&Map{map[string]interface{}{"k": []string{"value1", "value2"}, "k2": "value1"}}},
-			args{&MapEntry{"k2", &Slice{[]string{"value1"}}, nil}},
+			fields{&Map{m: map[string]interface{}{"k": []string{"value1", "value2"}, "k2": "value1"}}},
+			args{&MapEntry{"k2", &Slice{s: []string{"value1"}}, nil}},
 			false},
 	}
 	for _, tt := range tests {
@@ -1611,13 +1626,13 @@ func TestEntryView_ToArray(t *testing.T) {
 	}{
 		{"works with empty",
 			fields{emptyMap()},
-			&Slice{[]*MapEntry{}}},
+			&Slice{s: []*MapEntry{}}},
 		{"single key",
 			fields{map[string]interface{}{"k": 1}},
-			&Slice{[]*MapEntry{{"k", 1, view.m}}}},
+			&Slice{s: []*MapEntry{{"k", 1, view.m}}}},
 		{"multiple keys in order",
 			fields{map[string]interface{}{"b": 1, "a": "value"}},
-			&Slice{[]*MapEntry{{"a", "value", view.m}, {"b", 1, view.m}}}},
+			&Slice{s: []*MapEntry{{"a", "value", view.m}, {"b", 1, view.m}}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1644,17 +1659,17 @@ func TestEntryView_Remove(t *testing.T) {
 		wantM  map[string]interface{}
 	}{
 		{"works with empty",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			args{&MapEntry{"k", 1, nil}},
 			false,
 			emptyMap()},
 		{"does nothing for non-existant",
-			fields{&Map{map[string]interface{}{"k": 1}}},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
 			args{&MapEntry{"k", 2, nil}},
 			false,
 			map[string]interface{}{"k": 1}},
 		{"removes from map",
-			fields{&Map{map[string]interface{}{"k": 1, "key": "value"}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "key": "value"}}},
 			args{&MapEntry{"k", 1, nil}},
 			true,
 			map[string]interface{}{"key": "value"}},
@@ -1686,26 +1701,26 @@ func TestEntryView_RemoveAll(t *testing.T) {
 		wantM     map[string]interface{}
 	}{
 		{"wrong type",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			args{&Map{}},
 			false,
 			assert.Error,
 			emptyMap()},
 		{"works with empty",
-			fields{&Map{emptyMap()}},
-			args{&Slice{[]interface{}{&MapEntry{"k", 1, nil}, &MapEntry{"key", "value", nil}}}},
+			fields{&Map{m: emptyMap()}},
+			args{&Slice{s: []interface{}{&MapEntry{"k", 1, nil}, &MapEntry{"key", "value", nil}}}},
 			false,
 			assert.NoError,
 			emptyMap()},
 		{"does nothing for non-existant",
-			fields{&Map{map[string]interface{}{"k": 1}}},
-			args{&Slice{[]interface{}{&MapEntry{"k", 2, nil}, &MapEntry{"key", "value", nil}}}},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
+			args{&Slice{s: []interface{}{&MapEntry{"k", 2, nil}, &MapEntry{"key", "value", nil}}}},
 			false,
 			assert.NoError,
 			map[string]interface{}{"k": 1}},
 		{"removes from map",
-			fields{&Map{map[string]interface{}{"k": 1, "key": "value", "some": []string{"more"}}}},
-			args{&Slice{[]interface{}{&MapEntry{"k", 1, nil}, &MapEntry{"some", []string{"more"}, nil}}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "key": "value", "some": []string{"more"}}}},
+			args{&Slice{s: []interface{}{&MapEntry{"k", 1, nil}, &MapEntry{"some", []string{"more"}, nil}}}},
 			true,
 			assert.NoError,
 			map[string]interface{}{"key": "value"}},
@@ -1739,31 +1754,31 @@ func TestEntryView_RetainAll(t *testing.T) {
 		wantM     map[string]interface{}
 	}{
 		{"wrong type",
-			fields{&Map{emptyMap()}},
+			fields{&Map{m: emptyMap()}},
 			args{&Map{}},
 			false,
 			assert.Error,
 			emptyMap()},
 		{"works with empty",
-			fields{&Map{emptyMap()}},
-			args{&Slice{[]interface{}{&MapEntry{"k", 1, nil}, &MapEntry{"key", "value", nil}}}},
+			fields{&Map{m: emptyMap()}},
+			args{&Slice{s: []interface{}{&MapEntry{"k", 1, nil}, &MapEntry{"key", "value", nil}}}},
 			false,
 			assert.NoError,
 			emptyMap()},
 		{"does nothing for existing",
-			fields{&Map{map[string]interface{}{"k": 1}}},
-			args{&Slice{[]interface{}{&MapEntry{"k", 1, nil}, &MapEntry{"key", "value", nil}}}},
+			fields{&Map{m: map[string]interface{}{"k": 1}}},
+			args{&Slice{s: []interface{}{&MapEntry{"k", 1, nil}, &MapEntry{"key", "value", nil}}}},
 			false,
 			assert.NoError,
 			map[string]interface{}{"k": 1}},
 		{"retains only existing",
-			fields{&Map{map[string]interface{}{"k": 1, "and": 2}}},
-			args{&Slice{[]interface{}{&MapEntry{"some", "entry", nil}, &MapEntry{"key", "value", nil}}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "and": 2}}},
+			args{&Slice{s: []interface{}{&MapEntry{"some", "entry", nil}, &MapEntry{"key", "value", nil}}}},
 			true,
 			assert.NoError, map[string]interface{}{}},
 		{"retains",
-			fields{&Map{map[string]interface{}{"k": 1, "key": "value", "some": []string{"more"}}}},
-			args{&Slice{[]interface{}{&MapEntry{"k", 1, nil}, &MapEntry{"some", []string{"more"}, nil}}}},
+			fields{&Map{m: map[string]interface{}{"k": 1, "key": "value", "some": []string{"more"}}}},
+			args{&Slice{s: []interface{}{&MapEntry{"k", 1, nil}, &MapEntry{"some", []string{"more"}, nil}}}},
 			true,
 			assert.NoError,
 			map[string]interface{}{"k": 1, "some": []string{"more"}}},
@@ -1845,13 +1860,13 @@ func TestSlice_AddAll(t *testing.T) {
 	}{
 		{"add to empty slice",
 			fields{nil},
-			args{&Slice{[]interface{}{1, "2"}}},
+			args{&Slice{s: []interface{}{1, "2"}}},
 			true,
 			assert.NoError,
 			[]interface{}{1, "2"}},
 		{"add to slice",
 			fields{[]interface{}{1}},
-			args{&Slice{[]interface{}{"2", []int{3}}}},
+			args{&Slice{s: []interface{}{"2", []int{3}}}},
 			true,
 			assert.NoError,
 			[]interface{}{1, "2", []int{3}}},
@@ -1943,7 +1958,7 @@ func TestSlice_Contains(t *testing.T) {
 			true},
 		{"value exists and of slice type",
 			fields{[]interface{}{"some", []string{"slice"}}},
-			args{&Slice{[]string{"slice"}}},
+			args{&Slice{s: []string{"slice"}}},
 			true},
 	}
 	for _, tt := range tests {
@@ -1977,22 +1992,22 @@ func TestSlice_ContainsAll(t *testing.T) {
 			assert.Error},
 		{"no value exists",
 			fields{[]interface{}{"some", "slice"}},
-			args{&Slice{[]interface{}{"not", "exists"}}},
+			args{&Slice{s: []interface{}{"not", "exists"}}},
 			false,
 			assert.NoError},
 		{"some values exist",
 			fields{[]interface{}{"some", "slice"}},
-			args{&Slice{[]interface{}{"some", "not exists"}}},
+			args{&Slice{s: []interface{}{"some", "not exists"}}},
 			false,
 			assert.NoError},
 		{"all values exist and of comparable type",
 			fields{[]interface{}{1, 2, "slice"}},
-			args{&Slice{[]interface{}{1, "slice"}}},
+			args{&Slice{s: []interface{}{1, "slice"}}},
 			true,
 			assert.NoError},
 		{"values exist and of non-comparable type",
 			fields{[]interface{}{1, map[string]interface{}{"a": []int{1}}, "some", []string{"slice"}}},
-			args{&Slice{[]interface{}{[]string{"slice"}, map[string]interface{}{"a": []int{1}}}}},
+			args{&Slice{s: []interface{}{[]string{"slice"}, map[string]interface{}{"a": []int{1}}}}},
 			true,
 			assert.NoError},
 	}
@@ -2029,27 +2044,27 @@ func TestSlice_Equals(t *testing.T) {
 			assert.Error},
 		{"some values exist",
 			fields{[]interface{}{"some", "slice"}},
-			args{&Slice{[]interface{}{"some", "not exists"}}},
+			args{&Slice{s: []interface{}{"some", "not exists"}}},
 			false,
 			assert.NoError},
 		{"same values, different order",
 			fields{[]interface{}{"some", "slice"}},
-			args{&Slice{[]interface{}{"slice", "some"}}},
+			args{&Slice{s: []interface{}{"slice", "some"}}},
 			false,
 			assert.NoError},
 		{"all values exist and of comparable type",
 			fields{[]interface{}{1, 2, "slice"}},
-			args{&Slice{[]interface{}{1, 2, "slice"}}},
+			args{&Slice{s: []interface{}{1, 2, "slice"}}},
 			true,
 			assert.NoError},
 		{"slices are equal and values of comparable type",
 			fields{[]interface{}{1, "slice"}},
-			args{&Slice{[]interface{}{1, "slice"}}},
+			args{&Slice{s: []interface{}{1, "slice"}}},
 			true,
 			assert.NoError},
 		{"slices are equal and of non-comparable type",
 			fields{[]interface{}{1, map[string]interface{}{"a": []int{1}}, "some", []string{"slice"}}},
-			args{&Slice{[]interface{}{1, map[string]interface{}{"a": []int{1}}, "some", []string{"slice"}}}},
+			args{&Slice{s: []interface{}{1, map[string]interface{}{"a": []int{1}}, "some", []string{"slice"}}}},
 			true,
 			assert.NoError},
 	}
@@ -2157,7 +2172,7 @@ func TestSlice_Iterator(t *testing.T) {
 	}{
 		{"should return iterator",
 			fields{[]interface{}{1, 2}},
-			&CollectionIterator{s: &Slice{[]interface{}{1, 2}}}},
+			&CollectionIterator{s: &Slice{s: []interface{}{1, 2}}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2251,25 +2266,25 @@ func TestSlice_RemoveAll(t *testing.T) {
 			nil},
 		{"works with nil",
 			fields{nil},
-			args{&Slice{[]interface{}{1}}},
+			args{&Slice{s: []interface{}{1}}},
 			false,
 			assert.NoError,
 			nil},
 		{"works with empty",
 			fields{[]interface{}{}},
-			args{&Slice{[]interface{}{1}}},
+			args{&Slice{s: []interface{}{1}}},
 			false,
 			assert.NoError,
 			[]interface{}{}},
 		{"does nothing for non-existant",
 			fields{[]interface{}{1, 3}},
-			args{&Slice{[]interface{}{2}}},
+			args{&Slice{s: []interface{}{2}}},
 			false,
 			assert.NoError,
 			[]interface{}{1, 3}},
 		{"removes comparable",
 			fields{[]interface{}{1, 2, 3}},
-			args{&Slice{[]interface{}{1, 3}}},
+			args{&Slice{s: []interface{}{1, 3}}},
 			true,
 			assert.NoError,
 			[]interface{}{2}},
@@ -2281,13 +2296,13 @@ func TestSlice_RemoveAll(t *testing.T) {
 			[]interface{}{3}},
 		{"removes all comparable",
 			fields{[]interface{}{1, 2, 3}},
-			args{&Slice{[]interface{}{1, 2, 3}}},
+			args{&Slice{s: []interface{}{1, 2, 3}}},
 			true,
 			assert.NoError,
 			[]interface{}{}},
 		{"removes non comparable",
 			fields{[]interface{}{1, []int{2}, 3}},
-			args{&Slice{[]interface{}{1, []int{2}}}},
+			args{&Slice{s: []interface{}{1, []int{2}}}},
 			true,
 			assert.NoError,
 			[]interface{}{3}},
@@ -2328,25 +2343,25 @@ func TestSlice_RetainAll(t *testing.T) {
 			nil},
 		{"works with nil",
 			fields{nil},
-			args{&Slice{[]interface{}{1}}},
+			args{&Slice{s: []interface{}{1}}},
 			false,
 			assert.NoError,
 			nil},
 		{"works with empty",
 			fields{[]interface{}{}},
-			args{&Slice{[]interface{}{1}}},
+			args{&Slice{s: []interface{}{1}}},
 			false,
 			assert.NoError,
 			[]interface{}{}},
 		{"does nothing for existant",
 			fields{[]interface{}{1, 3}},
-			args{&Slice{[]interface{}{1, 3}}},
+			args{&Slice{s: []interface{}{1, 3}}},
 			false,
 			assert.NoError,
 			[]interface{}{1, 3}},
 		{"retains comparable",
 			fields{[]interface{}{1, 2, 3}},
-			args{&Slice{[]interface{}{1, 3}}},
+			args{&Slice{s: []interface{}{1, 3}}},
 			true,
 			assert.NoError,
 			[]interface{}{1, 3}},
@@ -2358,13 +2373,13 @@ func TestSlice_RetainAll(t *testing.T) {
 			[]interface{}{1, 2}},
 		{"retains only existant",
 			fields{[]interface{}{1, 2, 3}},
-			args{&Slice{[]interface{}{4, 5}}},
+			args{&Slice{s: []interface{}{4, 5}}},
 			true,
 			assert.NoError,
 			[]interface{}{}},
 		{"retains non comparable",
 			fields{[]interface{}{1, []int{2}, 3}},
-			args{&Slice{[]interface{}{1, []int{2}}}},
+			args{&Slice{s: []interface{}{1, []int{2}}}},
 			true,
 			assert.NoError,
 			[]interface{}{1, []int{2}}},
@@ -2509,16 +2524,16 @@ func TestSlice_ToArray(t *testing.T) {
 	}{
 		{"works with nil",
 			fields{nil},
-			&Slice{[]interface{}{}}},
+			&Slice{s: []interface{}{}}},
 		{"works with empty",
 			fields{[]interface{}{}},
-			&Slice{[]interface{}{}}},
+			&Slice{s: []interface{}{}}},
 		{"works with nil values",
 			fields{[]interface{}{1, nil, 2}},
-			&Slice{[]interface{}{1, nil, 2}}},
+			&Slice{s: []interface{}{1, nil, 2}}},
 		{"returns copy",
 			fields{[]interface{}{1, "2", []int{3}}},
-			&Slice{[]interface{}{1, "2", []int{3}}}},
+			&Slice{s: []interface{}{1, "2", []int{3}}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2547,16 +2562,16 @@ func TestNewIterator(t *testing.T) {
 	}{
 		{"creates iterator from go slice",
 			args{[]interface{}{1, 2, 3}},
-			&CollectionIterator{s: &Slice{[]interface{}{1, 2, 3}}}},
+			&CollectionIterator{s: &Slice{s: []interface{}{1, 2, 3}}}},
 		{"creates iterator from vtl slice",
-			args{&Slice{[]interface{}{4, 5, 6}}},
-			&CollectionIterator{s: &Slice{[]interface{}{4, 5, 6}}}},
+			args{&Slice{s: []interface{}{4, 5, 6}}},
+			&CollectionIterator{s: &Slice{s: []interface{}{4, 5, 6}}}},
 		{"creates iterator from scalar",
 			args{1},
-			&CollectionIterator{s: &Slice{[]int{1}}}},
+			&CollectionIterator{s: &Slice{s: []int{1}}}},
 		{"creates iterator from map as scalar",
 			args{map[string]interface{}{"1": 2, "3": 4, "5": 6}},
-			&CollectionIterator{s: &Slice{[]*Map{{map[string]interface{}{"1": 2, "3": 4, "5": 6}}}}}},
+			&CollectionIterator{s: &Slice{s: []*Map{{m: map[string]interface{}{"1": 2, "3": 4, "5": 6}}}}}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2577,19 +2592,19 @@ func TestCollectionIterator_Next(t *testing.T) {
 		wantErr []error
 	}{
 		{"nil",
-			fields{&Slice{[]int(nil)}, 0},
+			fields{&Slice{s: []int(nil)}, 0},
 			[]interface{}{nil, nil},
 			[]error{errIteratorOutOfRange, errIteratorOutOfRange}},
 		{"single",
-			fields{&Slice{[]interface{}{1}}, 0},
+			fields{&Slice{s: []interface{}{1}}, 0},
 			[]interface{}{1, nil, nil},
 			[]error{nil, errIteratorOutOfRange, errIteratorOutOfRange}},
 		{"two",
-			fields{&Slice{[]interface{}{1, 2}}, 0},
+			fields{&Slice{s: []interface{}{1, 2}}, 0},
 			[]interface{}{1, 2, nil, nil},
 			[]error{nil, nil, errIteratorOutOfRange, errIteratorOutOfRange}},
 		{"five",
-			fields{&Slice{[]interface{}{1, 2, 3, 4, 5}}, 0},
+			fields{&Slice{s: []interface{}{1, 2, 3, 4, 5}}, 0},
 			[]interface{}{1, 2, 3, 4, 5, nil, nil},
 			[]error{nil, nil, nil, nil, nil, errIteratorOutOfRange, errIteratorOutOfRange}},
 		{"range single",
@@ -2631,16 +2646,16 @@ func TestCollectionIterator_HasNext(t *testing.T) {
 		want   []bool
 	}{
 		{"nil",
-			fields{&Slice{[]int(nil)}, 0},
+			fields{&Slice{s: []int(nil)}, 0},
 			[]bool{false, false}},
 		{"single",
-			fields{&Slice{[]interface{}{1}}, 0},
+			fields{&Slice{s: []interface{}{1}}, 0},
 			[]bool{true, false, false}},
 		{"two",
-			fields{&Slice{[]interface{}{1, 2}}, 0},
+			fields{&Slice{s: []interface{}{1, 2}}, 0},
 			[]bool{true, true, false, false}},
 		{"five",
-			fields{&Slice{[]interface{}{1, 2, 3, 4, 5}}, 0},
+			fields{&Slice{s: []interface{}{1, 2, 3, 4, 5}}, 0},
 			[]bool{true, true, true, true, true, false, false}},
 		{"range single",
 			fields{newrange(1, 1), 0},
@@ -2680,20 +2695,20 @@ func TestCollectionIterator_Remove(t *testing.T) {
 		wantLast  int
 	}{
 		{"can't remove from range",
-			fields{&CollectionIterator{&Range{1, 3, 1}, 1, 1}},
+			fields{&CollectionIterator{s: &Range{1, 3, 1}, i: 1, last: 1}},
 			assert.Error, errUnsupported, &Range{1, 3, 1}, 1, 1},
 		{"remove from the middle of a slice",
-			fields{&CollectionIterator{&Slice{[]int{1, 2, 3}}, 2, 2}},
-			assert.NoError, nil, &Slice{[]int{1, 3}}, 1, 0},
+			fields{&CollectionIterator{s: &Slice{s: []int{1, 2, 3}}, i: 2, last: 2}},
+			assert.NoError, nil, &Slice{s: []int{1, 3}, mod: 1}, 1, 0},
 		{"remove from the end of a slice",
-			fields{&CollectionIterator{&Slice{[]int{1, 2, 3}}, 3, 3}},
-			assert.NoError, nil, &Slice{[]int{1, 2}}, 2, 0},
+			fields{&CollectionIterator{s: &Slice{s: []int{1, 2, 3}}, i: 3, last: 3}},
+			assert.NoError, nil, &Slice{s: []int{1, 2}, mod: 1}, 2, 0},
 		{"remove from the start of a slice",
-			fields{&CollectionIterator{&Slice{[]int{1, 2, 3}}, 1, 1}},
-			assert.NoError, nil, &Slice{[]int{2, 3}}, 0, 0},
+			fields{&CollectionIterator{s: &Slice{s: []int{1, 2, 3}}, i: 1, last: 1}},
+			assert.NoError, nil, &Slice{s: []int{2, 3}, mod: 1}, 0, 0},
 		{"remove from the unitialized slice",
-			fields{&CollectionIterator{&Slice{[]int{1, 2, 3}}, 0, 0}},
-			assert.Error, errIteratorInvalidState, &Slice{[]int{1, 2, 3}}, 0, 0},
+			fields{&CollectionIterator{s: &Slice{s: []int{1, 2, 3}}, i: 0, last: 0}},
+			assert.Error, errIteratorInvalidState, &Slice{s: []int{1, 2, 3}}, 0, 0},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3597,22 +3612,22 @@ func TestRange_ContainsAll(t *testing.T) {
 			assert.Error},
 		{"no value exists",
 			fields{-3, 3, 1},
-			args{&Slice{[]interface{}{4}}},
+			args{&Slice{s: []interface{}{4}}},
 			false,
 			assert.NoError},
 		{"some values exist",
 			fields{-3, 3, 1},
-			args{&Slice{[]int{-3, 0, 4}}},
+			args{&Slice{s: []int{-3, 0, 4}}},
 			false,
 			assert.NoError},
 		{"all values exist - some points",
 			fields{-3, 3, 1},
-			args{&Slice{[]int64{-3, 0, 3}}},
+			args{&Slice{s: []int64{-3, 0, 3}}},
 			true,
 			assert.NoError},
 		{"all values exist - full range",
 			fields{-3, 3, 1},
-			args{&Slice{[]int64{-3, -2, -1, 0, 1, 2, 3}}},
+			args{&Slice{s: []int64{-3, -2, -1, 0, 1, 2, 3}}},
 			true,
 			assert.NoError},
 	}
@@ -4033,9 +4048,9 @@ func TestRange_ToArray(t *testing.T) {
 		wantErr   error
 	}{
 		{"too large range", fields{0, 1024 * 1024, 1}, nil, assert.Error, errors.New("size is too large")},
-		{"maximum range", fields{0, 1024*1024 - 1, 1}, &Slice{s}, assert.NoError, nil},
-		{"some small slice upwards", fields{-3, 3, 1}, &Slice{[]int{-3, -2, -1, 0, 1, 2, 3}}, assert.NoError, nil},
-		{"some small slice downwards", fields{3, -3, -1}, &Slice{[]int{3, 2, 1, 0, -1, -2, -3}}, assert.NoError, nil},
+		{"maximum range", fields{0, 1024*1024 - 1, 1}, &Slice{s: s}, assert.NoError, nil},
+		{"some small slice upwards", fields{-3, 3, 1}, &Slice{s: []int{-3, -2, -1, 0, 1, 2, 3}}, assert.NoError, nil},
+		{"some small slice downwards", fields{3, -3, -1}, &Slice{s: []int{3, 2, 1, 0, -1, -2, -3}}, assert.NoError, nil},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -4072,36 +4087,36 @@ func TestNewMapIterator(t *testing.T) {
 		args args
 		want *MapIterator
 	}{
-		{"nil", args{&Map{mapNil}, nil},
+		{"nil", args{&Map{m: mapNil}, nil},
 			&MapIterator{mapper: nil, i: 0, last: 0,
 				mM: val(mapNil),
 				k:  []reflect.Value{}}},
-		{"map[string]int", args{&Map{mapStringInt}, nil},
+		{"map[string]int", args{&Map{m: mapStringInt}, nil},
 			&MapIterator{mapper: nil, i: 0, last: 0,
 				mM: val(mapStringInt),
 				k:  []reflect.Value{val("1"), val("10"), val("2")}}},
-		{"map[int]int", args{&Map{mapIntInt}, nil},
+		{"map[int]int", args{&Map{m: mapIntInt}, nil},
 			&MapIterator{mapper: nil, i: 0, last: 0,
 				mM: val(mapIntInt),
 				k:  []reflect.Value{val(int(1)), val(int(2)), val(int(10))}}},
-		{"map[byte]int", args{&Map{mapByteInt}, nil},
+		{"map[byte]int", args{&Map{m: mapByteInt}, nil},
 			&MapIterator{mapper: nil, i: 0, last: 0,
 				mM: val(mapByteInt),
 				k:  []reflect.Value{val(byte(1)), val(byte(2)), val(byte(10))}}},
-		{"map[uint]int", args{&Map{mapUintInt}, nil},
+		{"map[uint]int", args{&Map{m: mapUintInt}, nil},
 			&MapIterator{mapper: nil, i: 0, last: 0,
 				mM: val(mapUintInt),
 				k:  []reflect.Value{val(uint(1)), val(uint(2)), val(uint(10))}}},
-		{"map[float32]int", args{&Map{mapFloatInt}, nil},
+		{"map[float32]int", args{&Map{m: mapFloatInt}, nil},
 			&MapIterator{mapper: nil, i: 0, last: 0,
 				mM: val(mapFloatInt),
 				k:  []reflect.Value{val(float32(1.0)), val(float32(2.0)), val(float32(10.0))}}},
-		{"map[bool]int", args{&Map{mapBoolInt}, nil},
+		{"map[bool]int", args{&Map{m: mapBoolInt}, nil},
 			&MapIterator{mapper: nil, i: 0, last: 0,
 				mM: val(mapBoolInt),
 				k:  []reflect.Value{val(false), val(true)}}},
 		// FIXME make interface work as a key
-		// {"map[interface{}]interface{}", args{&Map{mapInterface}, nil},
+		// {"map[interface{}]interface{}", args{&Map{m: mapInterface}, nil},
 		// 	&MapIterator{mapper: nil, i: 0, last: 0,
 		// 		mM: val(mapInterface),
 		// 		k:  []reflect.Value{val(1), val(2)}}},
@@ -4261,3 +4276,40 @@ func TestMapIterator_Remove(t *testing.T) {
 		})
 	}
 }
+
+func TestNewOrderedMap(t *testing.T) {
+	m := NewOrderedMap()
+	for _, k := range []string{"c", "a", "b"} {
+		_, err := m.Put(k, len(k))
+		assert.NoError(t, err)
+	}
+	_, err := m.Put("c", 2)
+	assert.NoError(t, err)
+	keys, err := m.KeySet().ToArray()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "a", "b"}, keys.s)
+	_, err = m.Remove("a")
+	assert.NoError(t, err)
+	vals, err := m.Values().ToArray()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{2, 1}, vals.s)
+}
+
+func TestMap_KeyOrder(t *testing.T) {
+	m := &Map{m: map[interface{}]interface{}{
+		"b": 1, "a": 1, true: 1, false: 1, nil: 1, 2.5: 1, int8(-1): 1, uint(2): 1, float32(2): 1, int64(2): 1, 1: 1,
+		[2]int{1, 2}: 1, [1]int{1}: 1,
+	}}
+	var keys []interface{}
+	for _, k := range m.keys() {
+		keys = append(keys, k.Interface())
+	}
+	assert.Equal(t, []interface{}{nil, int8(-1), 1, int64(2), uint(2), float32(2), 2.5, false, true, "a", "b", [1]int{1}, [2]int{1, 2}}, keys)
+}
+
+func TestMap_PutNotComparable(t *testing.T) {
+	m := &Map{m: map[interface{}]interface{}{}}
+	_, err := m.Put([]int{1}, 1)
+	assert.EqualError(t, err, "cannot convert key []int which is not comparable")
+	assert.False(t, m.ContainsKey([]int{1}))
+}
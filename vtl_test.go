@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/sergi/go-diff/diffmatchpatch"
@@ -49,6 +50,45 @@ func TestTemplates(t *testing.T) {
 	}
 }
 
+func TestTemplatesParallel(t *testing.T) {
+	const workers = 8
+	for _, f := range templates {
+		f := f
+		t.Run(f, func(t *testing.T) {
+			t.Parallel()
+			data, err := ioutil.ReadFile("templates/" + f + ".vm")
+			if err != nil {
+				t.Fatal("Error reading", f, err)
+			}
+			expect, err := ioutil.ReadFile("templates/compare/" + f + ".cmp")
+			if err != nil {
+				t.Fatal("Error reading", f, err)
+			}
+			tmpl, err := govtl.Parse(string(data), "templates", "VM_global_library.vm")
+			if err != nil {
+				t.Fatal("parsing", f, "error", err)
+			}
+			var wg sync.WaitGroup
+			for i := 0; i < workers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					var b bytes.Buffer
+					err := tmpl.Execute(&b, SetupTest())
+					if err != nil && err.Error() != "stop" {
+						t.Error("Error running", f, err)
+						return
+					}
+					if string(expect) != b.String() {
+						t.Error("not equal", f)
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
+
 func DiffPrettyTextWS(diffs []diffmatchpatch.Diff) string {
 	var buff bytes.Buffer
 	for _, diff := range diffs {